@@ -25,6 +25,12 @@ import (
 
 	"github.com/banzaicloud/telescopes/internal/platform/log"
 	"github.com/banzaicloud/telescopes/internal/platform/metrics"
+	"github.com/banzaicloud/telescopes/pkg/recommender/cache"
+	"github.com/banzaicloud/telescopes/pkg/recommender/costs"
+	"github.com/banzaicloud/telescopes/pkg/recommender/custom"
+	"github.com/banzaicloud/telescopes/pkg/recommender/federation"
+	"github.com/banzaicloud/telescopes/pkg/recommender/maxpods"
+	"github.com/banzaicloud/telescopes/pkg/recommender/resilient"
 )
 
 // configuration holds any kind of configuration that comes from the outside world and
@@ -48,6 +54,9 @@ type configuration struct {
 
 		DevMode bool
 
+		// Deadline of the recommendation requests, disabled if 0
+		RequestTimeout time.Duration
+
 		// nolint: unused
 		Vault struct {
 			TokenSigningKey string
@@ -56,6 +65,42 @@ type configuration struct {
 
 	Cloudinfo struct {
 		Address string
+
+		// Deadline of the calls to the cloud info service, disabled if 0
+		Timeout time.Duration
+
+		// Directory of a cloud info snapshot used instead of the cloud info service if set
+		SnapshotDir string
+
+		// Retries and circuit breaking of the calls to the cloud info service
+		Resilience resilient.Config
+
+		// Cloud info backends and the providers routed to them, used instead of the address and the snapshot directory if set
+		Backends []federation.BackendConfig
+
+		// Private providers defined in the configuration, served besides the cloud info providers
+		CustomProviders []custom.Provider
+
+		// Cache of the cloud info responses
+		Cache cache.Config
+	}
+
+	// Recommender engine configuration
+	Recommender struct {
+		// Path of the spot interruption frequency data file (AWS Spot Advisor format)
+		SpotAdvisorFile string
+
+		// Path of the per-instance-family vCPU performance factor table
+		BenchmarkFile string
+
+		// Pod limits of instance types that differ from the built-in max pods model
+		MaxPods []maxpods.Override
+
+		// Hourly Windows license price per vCPU, used when cloud info provides no Windows prices
+		WindowsLicensePrice float64
+
+		// Cluster costs besides the instances per provider and service
+		CostComponents []costs.Component
 	}
 }
 
@@ -96,12 +141,44 @@ func Configure(v *viper.Viper, p *pflag.FlagSet) {
 	p.String("listen-address", ":9090", "the address where the server listens to HTTP requests.")
 	_ = v.BindPFlag("app.address", p.Lookup("listen-address"))
 	_ = v.BindEnv("app.address", "LISTEN_ADDRESS")
+	p.Duration("request-timeout", 60*time.Second, "the deadline of the recommendation requests, disabled if 0")
+	_ = v.BindPFlag("app.requesttimeout", p.Lookup("request-timeout"))
+	_ = v.BindEnv("app.requesttimeout", "REQUEST_TIMEOUT")
 
 	// Cloudinfo
 	p.String("cloudinfo-address", "http://localhost:9090/api/v1", "the address of the Cloud Info "+
 		"service to retrieve attribute and pricing info [format=scheme://host:port/basepath]")
 	_ = v.BindPFlag("cloudinfo.address", p.Lookup("cloudinfo-address"))
 	_ = v.BindEnv("cloudinfo.address", "CLOUDINFO_ADDRESS")
+	p.Duration("cloudinfo-timeout", 30*time.Second, "the deadline of the calls to the Cloud Info service, disabled if 0")
+	_ = v.BindPFlag("cloudinfo.timeout", p.Lookup("cloudinfo-timeout"))
+	_ = v.BindEnv("cloudinfo.timeout", "CLOUDINFO_TIMEOUT")
+	p.String("cloudinfo-snapshot-dir", "", "the directory of a cloud info snapshot used instead of the Cloud Info service (offline mode)")
+	_ = v.BindPFlag("cloudinfo.snapshotdir", p.Lookup("cloudinfo-snapshot-dir"))
+	_ = v.BindEnv("cloudinfo.snapshotdir", "CLOUDINFO_SNAPSHOT_DIR")
+	v.SetDefault("cloudinfo.resilience.retries", 2)
+	v.SetDefault("cloudinfo.resilience.backoff", 200*time.Millisecond)
+	v.SetDefault("cloudinfo.resilience.maxBackoff", 2*time.Second)
+	v.SetDefault("cloudinfo.resilience.breakerThreshold", 5)
+	v.SetDefault("cloudinfo.resilience.breakerTimeout", 30*time.Second)
+	v.SetDefault("cloudinfo.cache.enabled", true)
+	v.SetDefault("cloudinfo.cache.productsTTL", 5*time.Minute)
+	v.SetDefault("cloudinfo.cache.regionsTTL", time.Hour)
+	v.SetDefault("cloudinfo.cache.continentsTTL", time.Hour)
+	v.SetDefault("cloudinfo.cache.providersTTL", time.Hour)
+	v.SetDefault("cloudinfo.cache.maxEntries", 1000)
+	v.SetDefault("cloudinfo.cache.refreshInterval", 0)
+
+	// Recommender
+	p.String("spot-advisor-file", "", "the path of the spot interruption frequency data file in AWS Spot Advisor format")
+	_ = v.BindPFlag("recommender.spotadvisorfile", p.Lookup("spot-advisor-file"))
+	_ = v.BindEnv("recommender.spotadvisorfile", "SPOT_ADVISOR_FILE")
+	p.String("benchmark-file", "", "the path of the JSON table of per-instance-family vCPU performance factors")
+	_ = v.BindPFlag("recommender.benchmarkfile", p.Lookup("benchmark-file"))
+	_ = v.BindEnv("recommender.benchmarkfile", "BENCHMARK_FILE")
+	p.Float64("windows-license-price", 0.046, "the hourly Windows license price per vCPU, used when cloud info provides no Windows prices")
+	_ = v.BindPFlag("recommender.windowslicenseprice", p.Lookup("windows-license-price"))
+	_ = v.BindEnv("recommender.windowslicenseprice", "WINDOWS_LICENSE_PRICE")
 
 	// operating mode
 	p.Bool("dev-mode", false, "development mode, if true token based authentication is disabled, false by default")
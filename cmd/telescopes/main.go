@@ -23,23 +23,42 @@
 //	License: Apache 2.0 http://www.apache.org/licenses/LICENSE-2.0.html
 //	Contact: Banzai Cloud<info@banzaicloud.com>
 //
+//	Consumes:
+//	- application/json
+//
+//	Produces:
+//	- application/json
+//
 // swagger:meta
 package main
 
 import (
 	"fmt"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/banzaicloud/telescopes/internal/app/telescopes/api"
 	"github.com/banzaicloud/telescopes/internal/platform/buildinfo"
 	"github.com/banzaicloud/telescopes/internal/platform/log"
 	"github.com/banzaicloud/telescopes/pkg/recommender"
+	"github.com/banzaicloud/telescopes/pkg/recommender/benchmark"
+	"github.com/banzaicloud/telescopes/pkg/recommender/cache"
+	"github.com/banzaicloud/telescopes/pkg/recommender/costs"
+	"github.com/banzaicloud/telescopes/pkg/recommender/custom"
+	"github.com/banzaicloud/telescopes/pkg/recommender/federation"
+	"github.com/banzaicloud/telescopes/pkg/recommender/maxpods"
 	"github.com/banzaicloud/telescopes/pkg/recommender/nodepools"
+	"github.com/banzaicloud/telescopes/pkg/recommender/resilient"
+	"github.com/banzaicloud/telescopes/pkg/recommender/snapshot"
+	"github.com/banzaicloud/telescopes/pkg/recommender/spotadvisor"
 	"github.com/banzaicloud/telescopes/pkg/recommender/vms"
 	"github.com/gin-gonic/gin"
 	"github.com/goph/emperror"
+	"github.com/goph/logur"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
@@ -53,6 +72,15 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == snapshotCommand {
+		runSnapshot(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == mockCloudInfoCommand {
+		runMockCloudInfo(os.Args[2:])
+		return
+	}
+
 	// read configuration (commandline, env etc)
 	Configure(viper.GetViper(), pflag.CommandLine)
 
@@ -84,8 +112,47 @@ func main() {
 	logger.Info("initializing the application",
 		map[string]interface{}{"version": version, "commit_hash": commitHash, "build_date": buildDate})
 
-	piUrl := parseCloudInfoAddress(config.Cloudinfo.Address)
-	ciCli := recommender.NewCloudInfoClient(piUrl.String(), logger)
+	var ciCli recommender.CloudInfoSource
+	if len(config.Cloudinfo.Backends) > 0 {
+		backends := make([]federation.Backend, 0, len(config.Cloudinfo.Backends))
+		for _, b := range config.Cloudinfo.Backends {
+			logger.Info("configuring cloud info backend", map[string]interface{}{"backend": b.Name, "providers": b.Providers})
+			registerer := prometheus.WrapRegistererWith(prometheus.Labels{"backend": b.Name}, prometheus.DefaultRegisterer)
+			backends = append(backends, federation.Backend{
+				Name:      b.Name,
+				Providers: b.Providers,
+				Source:    newCloudInfoSource(config, b.Address, b.SnapshotDir, registerer, logger),
+			})
+		}
+		ciCli, err = federation.NewCloudInfoSource(backends, logger)
+		emperror.Panic(err)
+	} else {
+		ciCli = newCloudInfoSource(config, config.Cloudinfo.Address, config.Cloudinfo.SnapshotDir, prometheus.DefaultRegisterer, logger)
+	}
+	if len(config.Cloudinfo.CustomProviders) > 0 {
+		customCiCli, err := custom.NewCloudInfoSource(config.Cloudinfo.CustomProviders)
+		emperror.Panic(errors.Wrap(err, "invalid custom providers"))
+		logger.Info("configured custom providers", map[string]interface{}{"providers": customCiCli.Providers()})
+
+		ciCli, err = federation.NewCloudInfoSource([]federation.Backend{
+			{Name: "custom", Providers: customCiCli.Providers(), Source: customCiCli},
+			{Name: "cloudinfo", Source: ciCli},
+		}, logger)
+		emperror.Panic(err)
+	}
+	if config.Cloudinfo.Cache.Enabled {
+		cachedCiCli := cache.NewCloudInfoSource(ciCli, config.Cloudinfo.Cache, logger)
+		if config.Metrics.Enabled {
+			prometheus.MustRegister(cachedCiCli)
+		}
+		if len(config.Cloudinfo.Cache.Prefetch) > 0 {
+			go cachedCiCli.Prefetch(config.Cloudinfo.Cache.Prefetch)
+		}
+		if config.Cloudinfo.Cache.RefreshInterval > 0 {
+			go cachedCiCli.RefreshPeriodically(config.Cloudinfo.Cache.RefreshInterval, make(chan struct{}))
+		}
+		ciCli = cachedCiCli
+	}
 
 	// configure the gin validator
 	err = api.ConfigureValidator()
@@ -93,7 +160,27 @@ func main() {
 
 	vmSelector := vms.NewVmSelector(logger)
 	nodePoolSelector := nodepools.NewNodePoolSelector(logger)
-	engine := recommender.NewEngine(logger, ciCli, vmSelector, nodePoolSelector)
+
+	engineOpts := []recommender.EngineOption{
+		recommender.WithMaxPods(maxpods.NewTable(config.Recommender.MaxPods)),
+		recommender.WithWindowsLicensePrice(config.Recommender.WindowsLicensePrice),
+	}
+	if config.Recommender.SpotAdvisorFile != "" {
+		risks, err := spotadvisor.Load(config.Recommender.SpotAdvisorFile)
+		emperror.Panic(err)
+		engineOpts = append(engineOpts, recommender.WithInterruptionRisks(risks))
+	}
+	if config.Recommender.BenchmarkFile != "" {
+		factors, err := benchmark.Load(config.Recommender.BenchmarkFile)
+		emperror.Panic(err)
+		engineOpts = append(engineOpts, recommender.WithPerformanceFactors(factors))
+	}
+	if len(config.Recommender.CostComponents) > 0 {
+		costComponents, err := costs.NewTable(config.Recommender.CostComponents)
+		emperror.Panic(err)
+		engineOpts = append(engineOpts, recommender.WithCostComponents(costComponents))
+	}
+	engine := recommender.NewEngine(logger, ciCli, vmSelector, nodePoolSelector, engineOpts...)
 
 	buildInfo := buildinfo.New(version, commitHash, buildDate)
 	routeHandler := api.NewRouteHandler(engine, buildInfo, ciCli, logger)
@@ -114,6 +201,10 @@ func main() {
 		routeHandler.EnableMetrics(router, config.Metrics.Address)
 	}
 
+	if config.App.RequestTimeout > 0 {
+		routeHandler.EnableRequestTimeout(router, config.App.RequestTimeout)
+	}
+
 	routeHandler.ConfigureRoutes(router)
 	logger.Info("configured routes")
 
@@ -121,6 +212,29 @@ func main() {
 	emperror.Panic(errors.Wrap(err, "failed to run router"))
 }
 
+// newCloudInfoSource creates the source of the snapshot if the directory is set, or the resilient client of the cloud info service
+func newCloudInfoSource(config configuration, address string, snapshotDir string, registerer prometheus.Registerer, logger logur.Logger) recommender.CloudInfoSource {
+	if snapshotDir != "" {
+		logger.Info("using cloud info snapshot", map[string]interface{}{"dir": snapshotDir})
+		if _, err := os.Stat(filepath.Join(snapshotDir, snapshot.ManifestFile)); err == nil {
+			manifest, err := snapshot.Verify(snapshotDir)
+			emperror.Panic(errors.Wrap(err, "invalid cloud info snapshot"))
+			logger.Info("verified cloud info snapshot", map[string]interface{}{"createdAt": manifest.CreatedAt, "source": manifest.Source})
+		}
+		source, err := recommender.NewFileCloudInfoSource(snapshotDir, logger)
+		emperror.Panic(err)
+		return source
+	}
+
+	piUrl := parseCloudInfoAddress(address)
+	source := resilient.NewCloudInfoSource(
+		recommender.NewCloudInfoClient(piUrl.String(), config.Cloudinfo.Timeout, logger), config.Cloudinfo.Resilience, logger)
+	if config.Metrics.Enabled {
+		registerer.MustRegister(source)
+	}
+	return source
+}
+
 func parseCloudInfoAddress(ciUrl string) *url.URL {
 	ciUrl = strings.TrimSuffix(ciUrl, "/")
 	u, err := url.ParseRequestURI(ciUrl)
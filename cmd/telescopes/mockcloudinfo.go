@@ -0,0 +1,52 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/banzaicloud/telescopes/internal/platform/log"
+	"github.com/banzaicloud/telescopes/pkg/recommender/mockcloudinfo"
+	"github.com/gin-gonic/gin"
+	"github.com/goph/emperror"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+)
+
+const mockCloudInfoCommand = "mock-cloudinfo"
+
+// runMockCloudInfo serves the cloud info API from a snapshot or a synthetic catalog: telescopes mock-cloudinfo [--snapshot-dir <dir>]
+func runMockCloudInfo(args []string) {
+	flags := pflag.NewFlagSet(fmt.Sprintf("%s %s", appName, mockCloudInfoCommand), pflag.ExitOnError)
+	address := flags.String("listen-address", ":9091", "the address the mock cloud info service listens on")
+	snapshotDir := flags.String("snapshot-dir", "", "the cloud info snapshot served, a synthetic catalog is served if not set")
+	logLevel := flags.String("log-level", "info", "log level")
+	_ = flags.Parse(args)
+
+	logger := log.NewLogger(log.Config{Format: "logfmt", Level: *logLevel})
+	gin.SetMode(gin.ReleaseMode)
+
+	catalog := mockcloudinfo.NewSyntheticCatalog()
+	if *snapshotDir != "" {
+		var err error
+		catalog, err = mockcloudinfo.LoadSnapshot(*snapshotDir)
+		emperror.Panic(errors.Wrap(err, "failed to load cloud info snapshot"))
+	}
+
+	logger.Info("serving mock cloud info", map[string]interface{}{"address": *address, "basePath": mockcloudinfo.BasePath, "snapshotDir": *snapshotDir})
+	err := http.ListenAndServe(*address, mockcloudinfo.NewHandler(catalog, logger))
+	emperror.Panic(errors.Wrap(err, "failed to run mock cloud info service"))
+}
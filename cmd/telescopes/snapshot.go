@@ -0,0 +1,54 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/banzaicloud/telescopes/internal/platform/log"
+	"github.com/banzaicloud/telescopes/pkg/recommender/snapshot"
+	"github.com/goph/emperror"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+)
+
+const snapshotCommand = "snapshot"
+
+// runSnapshot exports a snapshot of the cloud info data: telescopes snapshot --output <dir> [filters]
+func runSnapshot(args []string) {
+	name := fmt.Sprintf("%s %s", appName, snapshotCommand)
+	flags := pflag.NewFlagSet(name, pflag.ExitOnError)
+	address := flags.String("cloudinfo-address", "http://localhost:9090/api/v1", "the address of the Cloud Info service to export [format=scheme://host:port/basepath]")
+	output := flags.String("output", "", "the directory the snapshot is written to")
+	providers := flags.StringSlice("provider", nil, "export the listed providers only")
+	services := flags.StringSlice("service", nil, "export the listed services only")
+	regions := flags.StringSlice("region", nil, "export the listed regions only")
+	logLevel := flags.String("log-level", "info", "log level")
+	_ = flags.Parse(args)
+
+	if *output == "" {
+		fmt.Fprintf(os.Stderr, "the --output flag is required\n\nUsage of %s:\n", name)
+		flags.PrintDefaults()
+		os.Exit(2)
+	}
+
+	logger := log.NewLogger(log.Config{Format: "logfmt", Level: *logLevel})
+	ciUrl := parseCloudInfoAddress(*address)
+
+	exporter := snapshot.NewExporter(ciUrl.String(), version, logger)
+	_, err := exporter.Export(*output, snapshot.Filter{Providers: *providers, Services: *services, Regions: *regions})
+	emperror.Panic(errors.Wrap(err, "failed to export cloud info snapshot"))
+}
@@ -26,6 +26,10 @@ import (
 	"github.com/mitchellh/mapstructure"
 )
 
+// The body schemas and the responses of the swagger:operation annotations are
+// written as inline JSON: gofmt re-indents nested YAML blocks in doc comments
+// into a layout the spec generator (go-swagger 0.19) reads without them.
+
 // swagger:operation POST /recommender/provider/{provider}/service/{service}/region/{region}/cluster recommend recommendCluster
 // ---
 // summary: Provides a recommended set of node pools on a given provider in a specific region.
@@ -46,16 +50,10 @@ import (
 //   - name: recommendRequestBody
 //     in: body
 //     description: request params
-//     schema:
-//     "$ref": "#/definitions/recommendClusterRequest"
+//     schema: {"$ref": "#/definitions/recommendClusterRequest"}
 //     required: true
 //
-// responses:
-//
-//	"200":
-//	  description: recommendation response
-//	  schema:
-//	    "$ref": "#/definitions/recommendationResponse"
+// responses: {"200": {"description": "recommendation response", "schema": {"$ref": "#/definitions/recommendationResponse"}}}
 func (r *RouteHandler) recommendCluster() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		pathParams := GetRecommendationParams{}
@@ -70,7 +68,7 @@ func (r *RouteHandler) recommendCluster() gin.HandlerFunc {
 
 		logger.Info("recommend cluster setup")
 
-		if err := NewCloudInfoValidator(r.ciCli).ValidatePathParams(pathParams); err != nil {
+		if err := NewCloudInfoValidator(r.ciCli).ValidatePathParams(c.Request.Context(), pathParams); err != nil {
 			errorresponse.NewErrorResponder(c).Respond(err)
 			return
 		}
@@ -84,7 +82,12 @@ func (r *RouteHandler) recommendCluster() gin.HandlerFunc {
 			return
 		}
 
-		response, err := r.engine.RecommendCluster(pathParams.Provider, pathParams.Service, pathParams.Region, req, nil)
+		if err := req.ResolveQuantities(); err != nil {
+			errorresponse.NewErrorResponder(c).Respond(emperror.With(err, classifier.ValidationErrTag))
+			return
+		}
+
+		response, err := r.engine.RecommendCluster(c.Request.Context(), pathParams.Provider, pathParams.Service, pathParams.Region, req, nil)
 		if err != nil {
 			errorresponse.NewErrorResponder(c).Respond(err)
 			return
@@ -113,16 +116,10 @@ func (r *RouteHandler) recommendCluster() gin.HandlerFunc {
 //   - name: recommendRequestBody
 //     in: body
 //     description: request params
-//     schema:
-//     "$ref": "#/definitions/recommendClusterScaleOutRequest"
+//     schema: {"$ref": "#/definitions/recommendClusterScaleOutRequest"}
 //     required: true
 //
-// responses:
-//
-//	"200":
-//	  description: recommendation response
-//	  schema:
-//	    "$ref": "#/definitions/recommendationResponse"
+// responses: {"200": {"description": "recommendation response", "schema": {"$ref": "#/definitions/recommendationResponse"}}}
 func (r *RouteHandler) recommendClusterScaleOut() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		pathParams := GetRecommendationParams{}
@@ -137,7 +134,7 @@ func (r *RouteHandler) recommendClusterScaleOut() gin.HandlerFunc {
 
 		logger.Info("recommend cluster scale out")
 
-		if e := NewCloudInfoValidator(r.ciCli).ValidatePathParams(pathParams); e != nil {
+		if e := NewCloudInfoValidator(r.ciCli).ValidatePathParams(c.Request.Context(), pathParams); e != nil {
 			errorresponse.NewErrorResponder(c).Respond(e)
 			return
 		}
@@ -150,7 +147,72 @@ func (r *RouteHandler) recommendClusterScaleOut() gin.HandlerFunc {
 			return
 		}
 
-		response, err := r.engine.RecommendClusterScaleOut(pathParams.Provider, pathParams.Service, pathParams.Region, req)
+		if err := req.ResolveQuantities(); err != nil {
+			errorresponse.NewErrorResponder(c).Respond(emperror.With(err, classifier.ValidationErrTag))
+			return
+		}
+
+		response, err := r.engine.RecommendClusterScaleOut(c.Request.Context(), pathParams.Provider, pathParams.Service, pathParams.Region, req)
+		if err != nil {
+			errorresponse.NewErrorResponder(c).Respond(err)
+			return
+		}
+		c.JSON(http.StatusOK, RecommendationResponse{*response})
+	}
+}
+
+// swagger:operation POST /recommender/provider/{provider}/service/{service}/region/{region}/quote recommend quoteCluster
+// ---
+// summary: Calculates the price of a cluster layout on a given provider in a specific region.
+// description: Calculates the price of a cluster layout on a given provider in a specific region.
+// parameters:
+//   - name: provider
+//     in: path
+//     description: provider
+//     required: true
+//   - name: service
+//     in: path
+//     description: service
+//     required: true
+//   - name: region
+//     in: path
+//     description: region
+//     required: true
+//   - name: quoteRequestBody
+//     in: body
+//     description: request params
+//     schema: {"$ref": "#/definitions/quoteClusterRequest"}
+//     required: true
+//
+// responses: {"200": {"description": "recommendation response", "schema": {"$ref": "#/definitions/recommendationResponse"}}}
+func (r *RouteHandler) quoteCluster() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		pathParams := GetRecommendationParams{}
+
+		if err := mapstructure.Decode(getPathParamMap(c), &pathParams); err != nil {
+			errorresponse.NewErrorResponder(c).Respond(emperror.Wrap(err, "failed to decode path parameters"))
+			return
+		}
+
+		logger := log.WithFieldsForHandlers(c, r.log,
+			map[string]interface{}{"provider": pathParams.Provider, "service": pathParams.Service, "region": pathParams.Region})
+
+		logger.Info("quote cluster layout")
+
+		if err := NewCloudInfoValidator(r.ciCli).ValidatePathParams(c.Request.Context(), pathParams); err != nil {
+			errorresponse.NewErrorResponder(c).Respond(err)
+			return
+		}
+
+		req := recommender.ClusterQuoteReq{}
+
+		if err := c.BindJSON(&req); err != nil {
+			errorresponse.NewErrorResponder(c).Respond(
+				emperror.WrapWith(err, "failed to bind request body", classifier.ValidationErrTag))
+			return
+		}
+
+		response, err := r.engine.QuoteCluster(c.Request.Context(), pathParams.Provider, pathParams.Service, pathParams.Region, req)
 		if err != nil {
 			errorresponse.NewErrorResponder(c).Respond(err)
 			return
@@ -159,6 +221,203 @@ func (r *RouteHandler) recommendClusterScaleOut() gin.HandlerFunc {
 	}
 }
 
+// swagger:operation POST /recommender/provider/{provider}/service/{service}/region/{region}/savings recommend recommendClusterSavings
+// ---
+// summary: Compares the costs of a cluster layout with the recommended one on a given provider in a specific region.
+// description: Compares the costs of a cluster layout with the recommended one on a given provider in a specific region.
+// parameters:
+//   - name: provider
+//     in: path
+//     description: provider
+//     required: true
+//   - name: service
+//     in: path
+//     description: service
+//     required: true
+//   - name: region
+//     in: path
+//     description: region
+//     required: true
+//   - name: savingsRequestBody
+//     in: body
+//     description: request params
+//     schema: {"$ref": "#/definitions/recommendClusterSavingsRequest"}
+//     required: true
+//
+// responses: {"200": {"description": "savings response", "schema": {"$ref": "#/definitions/savingsResponse"}}}
+func (r *RouteHandler) recommendClusterSavings() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		pathParams := GetRecommendationParams{}
+
+		if err := mapstructure.Decode(getPathParamMap(c), &pathParams); err != nil {
+			errorresponse.NewErrorResponder(c).Respond(emperror.Wrap(err, "failed to decode path parameters"))
+			return
+		}
+
+		logger := log.WithFieldsForHandlers(c, r.log,
+			map[string]interface{}{"provider": pathParams.Provider, "service": pathParams.Service, "region": pathParams.Region})
+
+		logger.Info("recommend cluster savings")
+
+		if err := NewCloudInfoValidator(r.ciCli).ValidatePathParams(c.Request.Context(), pathParams); err != nil {
+			errorresponse.NewErrorResponder(c).Respond(err)
+			return
+		}
+
+		req := recommender.ClusterSavingsReq{}
+
+		if err := c.BindJSON(&req); err != nil {
+			errorresponse.NewErrorResponder(c).Respond(
+				emperror.WrapWith(err, "failed to bind request body", classifier.ValidationErrTag))
+			return
+		}
+
+		if err := req.ResolveQuantities(); err != nil {
+			errorresponse.NewErrorResponder(c).Respond(emperror.With(err, classifier.ValidationErrTag))
+			return
+		}
+
+		response, err := r.engine.RecommendClusterSavings(c.Request.Context(), pathParams.Provider, pathParams.Service, pathParams.Region, req)
+		if err != nil {
+			errorresponse.NewErrorResponder(c).Respond(err)
+			return
+		}
+		c.JSON(http.StatusOK, SavingsResponse{*response})
+	}
+}
+
+// swagger:operation POST /recommender/provider/{provider}/service/{service}/region/{region}/migration recommend recommendClusterMigration
+// ---
+// summary: Provides a migration plan from a cluster layout to the recommended one on a given provider in a specific region.
+// description: Provides a migration plan from a cluster layout to the recommended one on a given provider in a specific region.
+// parameters:
+//   - name: provider
+//     in: path
+//     description: provider
+//     required: true
+//   - name: service
+//     in: path
+//     description: service
+//     required: true
+//   - name: region
+//     in: path
+//     description: region
+//     required: true
+//   - name: migrationRequestBody
+//     in: body
+//     description: request params
+//     schema: {"$ref": "#/definitions/recommendClusterMigrationRequest"}
+//     required: true
+//
+// responses: {"200": {"description": "migration response", "schema": {"$ref": "#/definitions/migrationResponse"}}}
+func (r *RouteHandler) recommendClusterMigration() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		pathParams := GetRecommendationParams{}
+
+		if err := mapstructure.Decode(getPathParamMap(c), &pathParams); err != nil {
+			errorresponse.NewErrorResponder(c).Respond(emperror.Wrap(err, "failed to decode path parameters"))
+			return
+		}
+
+		logger := log.WithFieldsForHandlers(c, r.log,
+			map[string]interface{}{"provider": pathParams.Provider, "service": pathParams.Service, "region": pathParams.Region})
+
+		logger.Info("recommend cluster migration")
+
+		if err := NewCloudInfoValidator(r.ciCli).ValidatePathParams(c.Request.Context(), pathParams); err != nil {
+			errorresponse.NewErrorResponder(c).Respond(err)
+			return
+		}
+
+		req := recommender.ClusterMigrationReq{}
+
+		if err := c.BindJSON(&req); err != nil {
+			errorresponse.NewErrorResponder(c).Respond(
+				emperror.WrapWith(err, "failed to bind request body", classifier.ValidationErrTag))
+			return
+		}
+
+		if err := req.ResolveQuantities(); err != nil {
+			errorresponse.NewErrorResponder(c).Respond(emperror.With(err, classifier.ValidationErrTag))
+			return
+		}
+
+		response, err := r.engine.RecommendClusterMigration(c.Request.Context(), pathParams.Provider, pathParams.Service, pathParams.Region, req)
+		if err != nil {
+			errorresponse.NewErrorResponder(c).Respond(err)
+			return
+		}
+		c.JSON(http.StatusOK, MigrationResponse{*response})
+	}
+}
+
+// swagger:operation POST /recommender/provider/{provider}/service/{service}/region/{region}/groups recommend recommendClusterWorkloadGroups
+// ---
+// summary: Provides dedicated node pools for the workload groups of a cluster on a given provider in a specific region.
+// description: Provides dedicated node pools for the workload groups of a cluster on a given provider in a specific region.
+// parameters:
+//   - name: provider
+//     in: path
+//     description: provider
+//     required: true
+//   - name: service
+//     in: path
+//     description: service
+//     required: true
+//   - name: region
+//     in: path
+//     description: region
+//     required: true
+//   - name: workloadGroupsRequestBody
+//     in: body
+//     description: request params
+//     schema: {"$ref": "#/definitions/recommendClusterWorkloadGroupsRequest"}
+//     required: true
+//
+// responses: {"200": {"description": "workload groups response", "schema": {"$ref": "#/definitions/workloadGroupsResponse"}}}
+func (r *RouteHandler) recommendClusterWorkloadGroups() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		pathParams := GetRecommendationParams{}
+
+		if err := mapstructure.Decode(getPathParamMap(c), &pathParams); err != nil {
+			errorresponse.NewErrorResponder(c).Respond(emperror.Wrap(err, "failed to decode path parameters"))
+			return
+		}
+
+		logger := log.WithFieldsForHandlers(c, r.log,
+			map[string]interface{}{"provider": pathParams.Provider, "service": pathParams.Service, "region": pathParams.Region})
+
+		logger.Info("recommend workload group node pools")
+
+		if err := NewCloudInfoValidator(r.ciCli).ValidatePathParams(c.Request.Context(), pathParams); err != nil {
+			errorresponse.NewErrorResponder(c).Respond(err)
+			return
+		}
+
+		req := recommender.ClusterWorkloadGroupsReq{}
+
+		if err := c.BindJSON(&req); err != nil {
+			errorresponse.NewErrorResponder(c).Respond(
+				emperror.WrapWith(err, "failed to bind request body", classifier.ValidationErrTag))
+			return
+		}
+
+		for i := range req.Groups {
+			if err := req.Groups[i].ResolveQuantities(); err != nil {
+				errorresponse.NewErrorResponder(c).Respond(emperror.With(err, classifier.ValidationErrTag, "group", req.Groups[i].Name))
+				return
+			}
+		}
+
+		response, err := r.engine.RecommendClusterWorkloadGroups(c.Request.Context(), pathParams.Provider, pathParams.Service, pathParams.Region, req)
+		if err != nil {
+			errorresponse.NewErrorResponder(c).Respond(err)
+			return
+		}
+		c.JSON(http.StatusOK, WorkloadGroupsResponse{*response})
+	}
+}
+
 // swagger:operation POST /recommender/multicloud recommend recommendMultiCluster
 // ---
 // summary: Provides a recommended set of node pools on a given provider in a specific region.
@@ -167,16 +426,10 @@ func (r *RouteHandler) recommendClusterScaleOut() gin.HandlerFunc {
 //   - name: recommendRequestBody
 //     in: body
 //     description: request params
-//     schema:
-//     "$ref": "#/definitions/recommendMultiClusterRequest"
+//     schema: {"$ref": "#/definitions/recommendMultiClusterRequest"}
 //     required: true
 //
-// responses:
-//
-//	"200":
-//	  description: recommendation response
-//	  schema:
-//	    "$ref": "#/definitions/recommendationResponse"
+// responses: {"200": {"description": "recommendation response", "schema": {"$ref": "#/definitions/recommendationResponse"}}}
 func (r *RouteHandler) recommendMultiCluster() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		logger := log.WithFieldsForHandlers(c, r.log, map[string]interface{}{})
@@ -190,12 +443,17 @@ func (r *RouteHandler) recommendMultiCluster() gin.HandlerFunc {
 			return
 		}
 
-		if err := NewCloudInfoValidator(r.ciCli).ValidateContinents(req.Continents); err != nil {
+		if err := req.ResolveQuantities(); err != nil {
+			errorresponse.NewErrorResponder(c).Respond(emperror.With(err, classifier.ValidationErrTag))
+			return
+		}
+
+		if err := NewCloudInfoValidator(r.ciCli).ValidateContinents(c.Request.Context(), req.Continents); err != nil {
 			errorresponse.NewErrorResponder(c).Respond(emperror.With(err, classifier.ValidationErrTag))
 			return
 		}
 
-		response, err := r.engine.RecommendMultiCluster(req)
+		response, err := r.engine.RecommendMultiCluster(c.Request.Context(), req)
 		if err != nil {
 			errorresponse.NewErrorResponder(c).Respond(err)
 			return
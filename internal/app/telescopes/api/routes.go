@@ -15,8 +15,10 @@
 package api
 
 import (
+	"context"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/banzaicloud/bank-vaults/pkg/auth"
 	ginprometheus "github.com/banzaicloud/go-gin-prometheus"
@@ -83,6 +85,10 @@ func (r *RouteHandler) ConfigureRoutes(router *gin.Engine) {
 		recGroup.POST("/multicloud", r.recommendMultiCluster())
 		recGroup.POST("/provider/:provider/service/:service/region/:region/cluster", r.recommendCluster())
 		recGroup.PUT("/provider/:provider/service/:service/region/:region/cluster", r.recommendClusterScaleOut())
+		recGroup.POST("/provider/:provider/service/:service/region/:region/quote", r.quoteCluster())
+		recGroup.POST("/provider/:provider/service/:service/region/:region/savings", r.recommendClusterSavings())
+		recGroup.POST("/provider/:provider/service/:service/region/:region/migration", r.recommendClusterMigration())
+		recGroup.POST("/provider/:provider/service/:service/region/:region/groups", r.recommendClusterWorkloadGroups())
 	}
 }
 
@@ -91,6 +97,17 @@ func (r *RouteHandler) EnableAuth(router *gin.Engine, role string, sgnKey string
 	router.Use(auth.JWTAuth(auth.NewVaultTokenStore(role), sgnKey, nil))
 }
 
+// EnableRequestTimeout sets the deadline of the requests, the recommendations exceeding it are cancelled
+func (r *RouteHandler) EnableRequestTimeout(router *gin.Engine, timeout time.Duration) {
+	router.Use(func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	})
+}
+
 func (r *RouteHandler) signalStatus(c *gin.Context) {
 	c.JSON(http.StatusOK, "ok")
 }
@@ -0,0 +1,141 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/banzaicloud/telescopes/internal/platform/buildinfo"
+	"github.com/banzaicloud/telescopes/pkg/recommender"
+	"github.com/banzaicloud/telescopes/pkg/recommender/mockcloudinfo"
+	"github.com/banzaicloud/telescopes/pkg/recommender/nodepools"
+	"github.com/banzaicloud/telescopes/pkg/recommender/vms"
+	"github.com/gin-gonic/gin"
+	"github.com/goph/logur"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRouteHandler_recommendCluster runs the recommendations end-to-end over HTTP against the mock cloud info service
+func TestRouteHandler_recommendCluster(t *testing.T) {
+	ciSrv := httptest.NewServer(mockcloudinfo.NewHandler(mockcloudinfo.NewSyntheticCatalog(), logur.NewTestLogger()))
+	defer ciSrv.Close()
+
+	logger := logur.NewTestLogger()
+	ciCli := recommender.NewCloudInfoClient(ciSrv.URL+mockcloudinfo.BasePath, 0, logger)
+	engine := recommender.NewEngine(logger, ciCli, vms.NewVmSelector(logger), nodepools.NewNodePoolSelector(logger))
+
+	assert.NoError(t, ConfigureValidator())
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	NewRouteHandler(engine, buildinfo.New("test", "", ""), ciCli, logger).ConfigureRoutes(router)
+
+	tests := []struct {
+		name  string
+		path  string
+		body  string
+		check func(code int, body []byte)
+	}{
+		{
+			name: "recommendation with kubernetes version",
+			path: "/api/v1/recommender/provider/amazon/service/eks/region/eu-west-1/cluster",
+			body: `{"sumCpu": 16, "sumMem": 32, "minNodes": 1, "maxNodes": 4, "onDemandPct": 100, "kubernetesVersion": "1.14.8"}`,
+			check: func(code int, body []byte) {
+				assert.Equal(t, http.StatusOK, code)
+
+				var resp RecommendationResponse
+				assert.NoError(t, json.Unmarshal(body, &resp))
+				assert.Equal(t, "1.14.8", resp.KubernetesVersion)
+				assert.NotEmpty(t, resp.NodePools)
+				for _, np := range resp.NodePools {
+					assert.Equal(t, "eks-node-1.14.8", np.Image)
+				}
+				assert.True(t, resp.Accuracy.RecCpu >= 16)
+			},
+		},
+		{
+			name: "on-demand nodes exceeding the average node count",
+			path: "/api/v1/recommender/provider/google/service/gke/region/us-central1/cluster",
+			body: `{"sumCpu": 8, "sumMem": 16, "minNodes": 1, "maxNodes": 3, "onDemandPct": 50}`,
+			check: func(code int, body []byte) {
+				assert.Equal(t, http.StatusOK, code)
+
+				var resp RecommendationResponse
+				assert.NoError(t, json.Unmarshal(body, &resp))
+				assert.NotEmpty(t, resp.NodePools)
+			},
+		},
+		{
+			name: "on-demand percentage of nodes with few nodes",
+			path: "/api/v1/recommender/provider/google/service/gke/region/us-central1/cluster",
+			body: `{"sumCpu": 8, "sumMem": 16, "minNodes": 1, "maxNodes": 3, "onDemandPct": 20, "onDemandPctMode": "nodes"}`,
+			check: func(code int, body []byte) {
+				assert.Equal(t, http.StatusOK, code)
+
+				var resp RecommendationResponse
+				assert.NoError(t, json.Unmarshal(body, &resp))
+				assert.NotEmpty(t, resp.NodePools)
+				assert.True(t, resp.Accuracy.RecCpu >= 8)
+			},
+		},
+		{
+			name: "kubernetes version not offered",
+			path: "/api/v1/recommender/provider/google/service/gke/region/europe-west1/cluster",
+			body: `{"sumCpu": 16, "sumMem": 32, "minNodes": 1, "maxNodes": 4, "onDemandPct": 100, "kubernetesVersion": "1.10.0"}`,
+			check: func(code int, body []byte) {
+				assert.Equal(t, http.StatusBadRequest, code)
+			},
+		},
+		{
+			name: "migration scaling out the kept node pools",
+			path: "/api/v1/recommender/provider/amazon/service/compute/region/eu-west-1/migration",
+			body: `{"sumCpu": 24, "sumMem": 96, "minNodes": 1, "maxNodes": 10, "onDemandPct": 100, "actualLayout": [` +
+				`{"instanceType": "m5.xlarge", "vmClass": "regular", "sumNodes": 2}, {"instanceType": "r5.2xlarge", "vmClass": "regular", "sumNodes": 3}]}`,
+			check: func(code int, body []byte) {
+				assert.Equal(t, http.StatusOK, code)
+
+				var resp MigrationResponse
+				assert.NoError(t, json.Unmarshal(body, &resp))
+				assert.True(t, resp.Complete)
+				assert.Equal(t, 1, len(resp.Target.NodePools))
+				assert.Equal(t, "m5.xlarge", resp.Target.NodePools[0].VmType.Type)
+				assert.Equal(t, 6, resp.Target.NodePools[0].SumNodes)
+				assert.True(t, resp.Target.Accuracy.RecTotalPrice < resp.Current.Accuracy.RecTotalPrice)
+				for _, step := range resp.Steps {
+					assert.True(t, step.Cpu >= 24 && step.Mem >= 96, "resources dropped below the minimum")
+				}
+			},
+		},
+		{
+			name: "unknown region",
+			path: "/api/v1/recommender/provider/azure/service/aks/region/mars-north/cluster",
+			body: `{"sumCpu": 16, "sumMem": 32, "minNodes": 1, "maxNodes": 4}`,
+			check: func(code int, body []byte) {
+				assert.Equal(t, http.StatusBadRequest, code)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test // scopelint
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, test.path, strings.NewReader(test.body)))
+			test.check(w.Code, w.Body.Bytes())
+		})
+	}
+}
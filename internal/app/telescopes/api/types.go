@@ -17,7 +17,7 @@ package api
 import "github.com/banzaicloud/telescopes/pkg/recommender"
 
 // GetRecommendationParams is a placeholder for the recommendation route's path parameters
-// swagger:parameters recommendCluster recommendClusterScaleOut
+// swagger:parameters recommendCluster recommendClusterScaleOut quoteCluster recommendClusterSavings recommendClusterMigration recommendClusterWorkloadGroups
 type GetRecommendationParams struct {
 	// in:path
 	Provider string `binding:"required,provider" json:"provider"`
@@ -34,3 +34,21 @@ type GetRecommendationParams struct {
 type RecommendationResponse struct {
 	recommender.ClusterRecommendationResp
 }
+
+// SavingsResponse encapsulates the savings response
+// swagger:model savingsResponse
+type SavingsResponse struct {
+	recommender.ClusterSavingsResp
+}
+
+// MigrationResponse encapsulates the migration plan response
+// swagger:model migrationResponse
+type MigrationResponse struct {
+	recommender.ClusterMigrationResp
+}
+
+// WorkloadGroupsResponse encapsulates the workload groups response
+// swagger:model workloadGroupsResponse
+type WorkloadGroupsResponse struct {
+	recommender.ClusterWorkloadGroupsResp
+}
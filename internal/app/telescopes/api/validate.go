@@ -15,6 +15,7 @@
 package api
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/banzaicloud/telescopes/internal/platform/classifier"
@@ -48,6 +49,15 @@ func ConfigureValidator() error {
 	if err := v.RegisterValidation("category", categoryValidator()); err != nil {
 		return emperror.Wrap(err, "could not register category validator")
 	}
+	if err := v.RegisterValidation("resilience", resilienceValidator()); err != nil {
+		return emperror.Wrap(err, "could not register resilience validator")
+	}
+	if err := v.RegisterValidation("onDemandPctMode", onDemandPctModeValidator()); err != nil {
+		return emperror.Wrap(err, "could not register onDemandPctMode validator")
+	}
+	if err := v.RegisterValidation("quantity", quantityValidator()); err != nil {
+		return emperror.Wrap(err, "could not register quantity validator")
+	}
 
 	return nil
 }
@@ -80,21 +90,59 @@ func categoryValidator() validator.Func {
 	}
 }
 
+// resilienceValidator validates the failures to survive in the recommendation request.
+func resilienceValidator() validator.Func {
+	return func(v *validator.Validate, topStruct reflect.Value, currentStruct reflect.Value, field reflect.Value,
+		fieldtype reflect.Type, fieldKind reflect.Kind, param string,
+	) bool {
+		for _, r := range []string{recommender.ResilienceNode, recommender.ResilienceZone, recommender.ResilienceSpotPool} {
+			if field.String() == r {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// onDemandPctModeValidator validates the interpretation of the on-demand percentage in the recommendation request.
+func onDemandPctModeValidator() validator.Func {
+	return func(v *validator.Validate, topStruct reflect.Value, currentStruct reflect.Value, field reflect.Value,
+		fieldtype reflect.Type, fieldKind reflect.Kind, param string,
+	) bool {
+		for _, m := range []string{recommender.OnDemandPctResources, recommender.OnDemandPctCost, recommender.OnDemandPctNodes} {
+			if field.String() == m {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// quantityValidator validates the Kubernetes resource quantities in the recommendation request.
+func quantityValidator() validator.Func {
+	return func(v *validator.Validate, topStruct reflect.Value, currentStruct reflect.Value, field reflect.Value,
+		fieldtype reflect.Type, fieldKind reflect.Kind, param string,
+	) bool {
+		_, err := recommender.ParseQuantity(field.String())
+		return err == nil
+	}
+}
+
 // CloudInfoValidator contract for validating cloud info data
 type CloudInfoValidator interface {
 	// Validate checks the existence, correctness etc... of the parameters
-	ValidatePathParams(params interface{}) error
+	ValidatePathParams(ctx context.Context, params interface{}) error
 
 	// ValidateContinents checks the existence of provided continents
-	ValidateContinents(continents []string) error
+	ValidateContinents(ctx context.Context, continents []string) error
 }
 
 type pathParamValidator struct {
 	ciCli recommender.CloudInfoSource
 }
 
-func (ppV *pathParamValidator) ValidateContinents(continents []string) error {
-	ciContinents, err := ppV.ciCli.GetContinents()
+func (ppV *pathParamValidator) ValidateContinents(ctx context.Context, continents []string) error {
+	ciContinents, err := ppV.ciCli.GetContinents(ctx)
 	if err != nil {
 		return err
 	}
@@ -126,7 +174,7 @@ func (ppV *pathParamValidator) ValidateContinents(continents []string) error {
 }
 
 // Validate validates path parameters against the connected cloud info service
-func (ppV *pathParamValidator) ValidatePathParams(params interface{}) error {
+func (ppV *pathParamValidator) ValidatePathParams(ctx context.Context, params interface{}) error {
 	var (
 		pathParams GetRecommendationParams
 		ok         bool
@@ -136,23 +184,23 @@ func (ppV *pathParamValidator) ValidatePathParams(params interface{}) error {
 		return errors.New("invalid path params")
 	}
 
-	if e := ppV.validateProvider(pathParams.Provider); e != nil {
+	if e := ppV.validateProvider(ctx, pathParams.Provider); e != nil {
 		return emperror.With(e, classifier.ValidationErrTag)
 	}
 
-	if e := ppV.validateService(pathParams.Provider, pathParams.Service); e != nil {
+	if e := ppV.validateService(ctx, pathParams.Provider, pathParams.Service); e != nil {
 		return emperror.With(e, classifier.ValidationErrTag)
 	}
 
-	if e := ppV.validateRegion(pathParams.Provider, pathParams.Service, pathParams.Region); e != nil {
+	if e := ppV.validateRegion(ctx, pathParams.Provider, pathParams.Service, pathParams.Region); e != nil {
 		return emperror.With(e, classifier.ValidationErrTag)
 	}
 
 	return nil
 }
 
-func (ppV *pathParamValidator) validateProvider(prv string) error {
-	if ciPrv, e := ppV.ciCli.GetProvider(prv); e != nil {
+func (ppV *pathParamValidator) validateProvider(ctx context.Context, prv string) error {
+	if ciPrv, e := ppV.ciCli.GetProvider(ctx, prv); e != nil {
 		return e
 	} else if ciPrv == "" {
 		return errors.New("provider not found")
@@ -160,8 +208,8 @@ func (ppV *pathParamValidator) validateProvider(prv string) error {
 	return nil
 }
 
-func (ppV *pathParamValidator) validateService(prv, svc string) error {
-	if cis, e := ppV.ciCli.GetService(prv, svc); e != nil {
+func (ppV *pathParamValidator) validateService(ctx context.Context, prv, svc string) error {
+	if cis, e := ppV.ciCli.GetService(ctx, prv, svc); e != nil {
 		return e
 	} else if cis == "" {
 		return errors.New("service not found")
@@ -169,8 +217,8 @@ func (ppV *pathParamValidator) validateService(prv, svc string) error {
 	return nil
 }
 
-func (ppV *pathParamValidator) validateRegion(prv, svc, region string) error {
-	if ciReg, e := ppV.ciCli.GetRegion(prv, svc, region); e != nil {
+func (ppV *pathParamValidator) validateRegion(ctx context.Context, prv, svc, region string) error {
+	if ciReg, e := ppV.ciCli.GetRegion(ctx, prv, svc, region); e != nil {
 		return e
 	} else if ciReg == "" {
 		return errors.New("region not found")
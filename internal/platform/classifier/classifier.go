@@ -15,6 +15,7 @@
 package classifier
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 
@@ -26,6 +27,7 @@ import (
 
 const (
 	cloudInfoCliErrTag  = "cloud-info-client"
+	circuitOpenErrTag   = "cloud-info-circuit-open"
 	recommenderErrorTag = "recommender"
 	ValidationErrTag    = "validation"
 )
@@ -57,6 +59,10 @@ func (erc *errClassifier) Classify(inErr interface{}) (interface{}, error) {
 	}
 
 	cause := errors.Cause(err)
+	if ctxErr := contextError(cause); ctxErr != nil {
+		// the request timed out or was cancelled, regardless of the step it was in
+		return erc.classifyContextError(ctxErr, emperror.Context(err)), nil
+	}
 
 	switch e := cause.(type) {
 	case *runtime.APIError:
@@ -117,7 +123,25 @@ func (erc *errClassifier) classifyUrlError(e *url.Error, ctx []interface{}) *pro
 	return problem
 }
 
+// classifyContextError assembles the response of the requests cancelled or exceeding their deadline
+func (erc *errClassifier) classifyContextError(e error, ctx []interface{}) *problems.ProblemWrapper {
+	if e == context.Canceled {
+		return problems.NewTimeoutProblem(http.StatusServiceUnavailable, "recommendation cancelled")
+	}
+
+	if hasLabel(ctx, cloudInfoCliErrTag) {
+		return problems.NewTimeoutProblem(http.StatusGatewayTimeout, "timed out waiting for the cloud info service")
+	}
+
+	return problems.NewTimeoutProblem(http.StatusGatewayTimeout, "recommendation timed out")
+}
+
 func (erc *errClassifier) classifyGenericError(e error, ctx []interface{}) *problems.ProblemWrapper {
+	if hasLabel(ctx, circuitOpenErrTag) {
+		// the calls to the failing cloud info endpoint are suspended
+		return problems.NewUnavailableProblem(http.StatusServiceUnavailable, "the cloud info service is unavailable, try again later")
+	}
+
 	problem := problems.NewUnknownProblem(e)
 
 	if hasLabel(ctx, recommenderErrorTag) {
@@ -139,3 +163,14 @@ func hasLabel(ctx []interface{}, s interface{}) bool {
 	}
 	return false
 }
+
+// contextError returns the context error causing the error if any, the cloud info client reports it wrapped in an url error
+func contextError(cause error) error {
+	if e, ok := cause.(*url.Error); ok {
+		cause = e.Err
+	}
+	if cause == context.Canceled || cause == context.DeadlineExceeded {
+		return cause
+	}
+	return nil
+}
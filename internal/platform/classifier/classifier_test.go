@@ -15,6 +15,7 @@
 package classifier
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 	"testing"
@@ -64,6 +65,41 @@ func TestErrResponseClassifier_Classify(t *testing.T) {
 				assert.Equal(t, http.StatusBadRequest, pb.Status, "invalid http status code")
 			},
 		},
+		{
+			name:  "url error - cloud info service timed out",
+			error: emperror.With(&url.Error{Err: context.DeadlineExceeded}, cloudInfoCliErrTag),
+			checker: func(t *testing.T, pb *problems.ProblemWrapper, e error) {
+				assert.Nil(t, e, "could not create classifier")
+				assert.Equal(t, http.StatusGatewayTimeout, pb.Status, "invalid http status code")
+				assert.Equal(t, "timed out waiting for the cloud info service", pb.Detail)
+			},
+		},
+		{
+			name:  "context error - recommendation timed out, validation",
+			error: emperror.With(errors.Wrap(context.DeadlineExceeded, "failed"), ValidationErrTag),
+			checker: func(t *testing.T, pb *problems.ProblemWrapper, e error) {
+				assert.Nil(t, e, "could not create classifier")
+				assert.Equal(t, http.StatusGatewayTimeout, pb.Status, "invalid http status code")
+				assert.Equal(t, "recommendation timed out", pb.Detail)
+			},
+		},
+		{
+			name:  "context error - request cancelled",
+			error: emperror.With(context.Canceled, recommenderErrorTag),
+			checker: func(t *testing.T, pb *problems.ProblemWrapper, e error) {
+				assert.Nil(t, e, "could not create classifier")
+				assert.Equal(t, http.StatusServiceUnavailable, pb.Status, "invalid http status code")
+			},
+		},
+		{
+			name:  "generic error - cloud info circuit open",
+			error: emperror.With(emperror.With(errors.New("circuit breaker is open"), circuitOpenErrTag), recommenderErrorTag),
+			checker: func(t *testing.T, pb *problems.ProblemWrapper, e error) {
+				assert.Nil(t, e, "could not create classifier")
+				assert.Equal(t, http.StatusServiceUnavailable, pb.Status, "invalid http status code")
+				assert.Equal(t, "cloud info unavailable problem", pb.Title)
+			},
+		},
 		{
 			name:  "generic error -  no tags",
 			error: emperror.With(errors.New("test error - no context")),
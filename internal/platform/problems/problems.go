@@ -24,6 +24,8 @@ import (
 const (
 	validationProblemTitle     = "validation problem"
 	recommendationProblemTitle = "recommendation problem"
+	timeoutProblemTitle        = "timeout problem"
+	unavailableProblemTitle    = "cloud info unavailable problem"
 )
 
 type ProblemWrapper struct {
@@ -42,6 +44,18 @@ func NewRecommendationProblem(code int, details string) *ProblemWrapper {
 	return &ProblemWrapper{pb}
 }
 
+func NewTimeoutProblem(code int, details string) *ProblemWrapper {
+	pb := problems.NewDetailedProblem(code, details)
+	pb.Title = timeoutProblemTitle
+	return &ProblemWrapper{pb}
+}
+
+func NewUnavailableProblem(code int, details string) *ProblemWrapper {
+	pb := problems.NewDetailedProblem(code, details)
+	pb.Title = unavailableProblemTitle
+	return &ProblemWrapper{pb}
+}
+
 func NewUnknownProblem(un interface{}) *ProblemWrapper {
 	return &ProblemWrapper{problems.NewDetailedProblem(http.StatusInternalServerError, fmt.Sprintf("%s", un))}
 }
@@ -0,0 +1,203 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package recommend
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	models "github.com/banzaicloud/telescopes/pkg/recommender-client/models"
+)
+
+// NewQuoteClusterParams creates a new QuoteClusterParams object
+// with the default values initialized.
+func NewQuoteClusterParams() *QuoteClusterParams {
+	var ()
+	return &QuoteClusterParams{
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewQuoteClusterParamsWithTimeout creates a new QuoteClusterParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewQuoteClusterParamsWithTimeout(timeout time.Duration) *QuoteClusterParams {
+	var ()
+	return &QuoteClusterParams{
+
+		timeout: timeout,
+	}
+}
+
+// NewQuoteClusterParamsWithContext creates a new QuoteClusterParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewQuoteClusterParamsWithContext(ctx context.Context) *QuoteClusterParams {
+	var ()
+	return &QuoteClusterParams{
+
+		Context: ctx,
+	}
+}
+
+// NewQuoteClusterParamsWithHTTPClient creates a new QuoteClusterParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewQuoteClusterParamsWithHTTPClient(client *http.Client) *QuoteClusterParams {
+	var ()
+	return &QuoteClusterParams{
+		HTTPClient: client,
+	}
+}
+
+/*
+QuoteClusterParams contains all the parameters to send to the API endpoint
+for the quote cluster operation typically these are written to a http.Request
+*/
+type QuoteClusterParams struct {
+
+	/*Provider
+	  provider
+
+	*/
+	Provider string
+	/*QuoteRequestBody
+	  request params
+
+	*/
+	QuoteRequestBody *models.ClusterQuoteReq
+	/*Region
+	  region
+
+	*/
+	Region string
+	/*Service
+	  service
+
+	*/
+	Service string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the quote cluster params
+func (o *QuoteClusterParams) WithTimeout(timeout time.Duration) *QuoteClusterParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the quote cluster params
+func (o *QuoteClusterParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the quote cluster params
+func (o *QuoteClusterParams) WithContext(ctx context.Context) *QuoteClusterParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the quote cluster params
+func (o *QuoteClusterParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the quote cluster params
+func (o *QuoteClusterParams) WithHTTPClient(client *http.Client) *QuoteClusterParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the quote cluster params
+func (o *QuoteClusterParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithProvider adds the provider to the quote cluster params
+func (o *QuoteClusterParams) WithProvider(provider string) *QuoteClusterParams {
+	o.SetProvider(provider)
+	return o
+}
+
+// SetProvider adds the provider to the quote cluster params
+func (o *QuoteClusterParams) SetProvider(provider string) {
+	o.Provider = provider
+}
+
+// WithQuoteRequestBody adds the quoteRequestBody to the quote cluster params
+func (o *QuoteClusterParams) WithQuoteRequestBody(quoteRequestBody *models.ClusterQuoteReq) *QuoteClusterParams {
+	o.SetQuoteRequestBody(quoteRequestBody)
+	return o
+}
+
+// SetQuoteRequestBody adds the quoteRequestBody to the quote cluster params
+func (o *QuoteClusterParams) SetQuoteRequestBody(quoteRequestBody *models.ClusterQuoteReq) {
+	o.QuoteRequestBody = quoteRequestBody
+}
+
+// WithRegion adds the region to the quote cluster params
+func (o *QuoteClusterParams) WithRegion(region string) *QuoteClusterParams {
+	o.SetRegion(region)
+	return o
+}
+
+// SetRegion adds the region to the quote cluster params
+func (o *QuoteClusterParams) SetRegion(region string) {
+	o.Region = region
+}
+
+// WithService adds the service to the quote cluster params
+func (o *QuoteClusterParams) WithService(service string) *QuoteClusterParams {
+	o.SetService(service)
+	return o
+}
+
+// SetService adds the service to the quote cluster params
+func (o *QuoteClusterParams) SetService(service string) {
+	o.Service = service
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *QuoteClusterParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	// path param provider
+	if err := r.SetPathParam("provider", o.Provider); err != nil {
+		return err
+	}
+
+	if o.QuoteRequestBody != nil {
+		if err := r.SetBodyParam(o.QuoteRequestBody); err != nil {
+			return err
+		}
+	}
+
+	// path param region
+	if err := r.SetPathParam("region", o.Region); err != nil {
+		return err
+	}
+
+	// path param service
+	if err := r.SetPathParam("service", o.Service); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package recommend
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	models "github.com/banzaicloud/telescopes/pkg/recommender-client/models"
+)
+
+// QuoteClusterReader is a Reader for the QuoteCluster structure.
+type QuoteClusterReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *QuoteClusterReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+
+	case 200:
+		result := NewQuoteClusterOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewQuoteClusterOK creates a QuoteClusterOK with default headers values
+func NewQuoteClusterOK() *QuoteClusterOK {
+	return &QuoteClusterOK{}
+}
+
+/*
+QuoteClusterOK handles this case with default header values.
+
+recommendation response
+*/
+type QuoteClusterOK struct {
+	Payload *models.RecommendationResponse
+}
+
+func (o *QuoteClusterOK) Error() string {
+	return fmt.Sprintf("[POST /recommender/provider/{provider}/service/{service}/region/{region}/quote][%d] quoteClusterOK  %+v", 200, o.Payload)
+}
+
+func (o *QuoteClusterOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.RecommendationResponse)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
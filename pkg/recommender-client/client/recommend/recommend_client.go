@@ -24,8 +24,40 @@ type Client struct {
 	formats   strfmt.Registry
 }
 
+/*
+QuoteCluster calculates the price of a cluster layout on a given provider in a specific region
+
+Calculates the price of a cluster layout on a given provider in a specific region.
+*/
+func (a *Client) QuoteCluster(params *QuoteClusterParams) (*QuoteClusterOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewQuoteClusterParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "quoteCluster",
+		Method:             "POST",
+		PathPattern:        "/recommender/provider/{provider}/service/{service}/region/{region}/quote",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &QuoteClusterReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*QuoteClusterOK), nil
+
+}
+
 /*
 RecommendCluster provides a recommended set of node pools on a given provider in a specific region
+
+Provides a recommended set of node pools on a given provider in a specific region.
 */
 func (a *Client) RecommendCluster(params *RecommendClusterParams) (*RecommendClusterOK, error) {
 	// TODO: Validate the params before sending
@@ -52,8 +84,70 @@ func (a *Client) RecommendCluster(params *RecommendClusterParams) (*RecommendClu
 
 }
 
+/*
+RecommendClusterMigration provides a migration plan from a cluster layout to the recommended one on a given provider in a specific region
+
+Provides a migration plan from a cluster layout to the recommended one on a given provider in a specific region.
+*/
+func (a *Client) RecommendClusterMigration(params *RecommendClusterMigrationParams) (*RecommendClusterMigrationOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewRecommendClusterMigrationParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "recommendClusterMigration",
+		Method:             "POST",
+		PathPattern:        "/recommender/provider/{provider}/service/{service}/region/{region}/migration",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &RecommendClusterMigrationReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*RecommendClusterMigrationOK), nil
+
+}
+
+/*
+RecommendClusterSavings compares the costs of a cluster layout with the recommended one on a given provider in a specific region
+
+Compares the costs of a cluster layout with the recommended one on a given provider in a specific region.
+*/
+func (a *Client) RecommendClusterSavings(params *RecommendClusterSavingsParams) (*RecommendClusterSavingsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewRecommendClusterSavingsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "recommendClusterSavings",
+		Method:             "POST",
+		PathPattern:        "/recommender/provider/{provider}/service/{service}/region/{region}/savings",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &RecommendClusterSavingsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*RecommendClusterSavingsOK), nil
+
+}
+
 /*
 RecommendClusterScaleOut provides a recommendation for a scale out based on a current cluster layout on a given provider in a specific region
+
+Provides a recommendation for a scale-out, based on a current cluster layout on a given provider in a specific region.
 */
 func (a *Client) RecommendClusterScaleOut(params *RecommendClusterScaleOutParams) (*RecommendClusterScaleOutOK, error) {
 	// TODO: Validate the params before sending
@@ -80,8 +174,40 @@ func (a *Client) RecommendClusterScaleOut(params *RecommendClusterScaleOutParams
 
 }
 
+/*
+RecommendClusterWorkloadGroups provides dedicated node pools for the workload groups of a cluster on a given provider in a specific region
+
+Provides dedicated node pools for the workload groups of a cluster on a given provider in a specific region.
+*/
+func (a *Client) RecommendClusterWorkloadGroups(params *RecommendClusterWorkloadGroupsParams) (*RecommendClusterWorkloadGroupsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewRecommendClusterWorkloadGroupsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "recommendClusterWorkloadGroups",
+		Method:             "POST",
+		PathPattern:        "/recommender/provider/{provider}/service/{service}/region/{region}/groups",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &RecommendClusterWorkloadGroupsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*RecommendClusterWorkloadGroupsOK), nil
+
+}
+
 /*
 RecommendMultiCluster provides a recommended set of node pools on a given provider in a specific region
+
+Provides a recommended set of node pools on a given provider in a specific region.
 */
 func (a *Client) RecommendMultiCluster(params *RecommendMultiClusterParams) (*RecommendMultiClusterOK, error) {
 	// TODO: Validate the params before sending
@@ -0,0 +1,203 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package recommend
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	models "github.com/banzaicloud/telescopes/pkg/recommender-client/models"
+)
+
+// NewRecommendClusterMigrationParams creates a new RecommendClusterMigrationParams object
+// with the default values initialized.
+func NewRecommendClusterMigrationParams() *RecommendClusterMigrationParams {
+	var ()
+	return &RecommendClusterMigrationParams{
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewRecommendClusterMigrationParamsWithTimeout creates a new RecommendClusterMigrationParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewRecommendClusterMigrationParamsWithTimeout(timeout time.Duration) *RecommendClusterMigrationParams {
+	var ()
+	return &RecommendClusterMigrationParams{
+
+		timeout: timeout,
+	}
+}
+
+// NewRecommendClusterMigrationParamsWithContext creates a new RecommendClusterMigrationParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewRecommendClusterMigrationParamsWithContext(ctx context.Context) *RecommendClusterMigrationParams {
+	var ()
+	return &RecommendClusterMigrationParams{
+
+		Context: ctx,
+	}
+}
+
+// NewRecommendClusterMigrationParamsWithHTTPClient creates a new RecommendClusterMigrationParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewRecommendClusterMigrationParamsWithHTTPClient(client *http.Client) *RecommendClusterMigrationParams {
+	var ()
+	return &RecommendClusterMigrationParams{
+		HTTPClient: client,
+	}
+}
+
+/*
+RecommendClusterMigrationParams contains all the parameters to send to the API endpoint
+for the recommend cluster migration operation typically these are written to a http.Request
+*/
+type RecommendClusterMigrationParams struct {
+
+	/*MigrationRequestBody
+	  request params
+
+	*/
+	MigrationRequestBody *models.ClusterMigrationReq
+	/*Provider
+	  provider
+
+	*/
+	Provider string
+	/*Region
+	  region
+
+	*/
+	Region string
+	/*Service
+	  service
+
+	*/
+	Service string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the recommend cluster migration params
+func (o *RecommendClusterMigrationParams) WithTimeout(timeout time.Duration) *RecommendClusterMigrationParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the recommend cluster migration params
+func (o *RecommendClusterMigrationParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the recommend cluster migration params
+func (o *RecommendClusterMigrationParams) WithContext(ctx context.Context) *RecommendClusterMigrationParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the recommend cluster migration params
+func (o *RecommendClusterMigrationParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the recommend cluster migration params
+func (o *RecommendClusterMigrationParams) WithHTTPClient(client *http.Client) *RecommendClusterMigrationParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the recommend cluster migration params
+func (o *RecommendClusterMigrationParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithMigrationRequestBody adds the migrationRequestBody to the recommend cluster migration params
+func (o *RecommendClusterMigrationParams) WithMigrationRequestBody(migrationRequestBody *models.ClusterMigrationReq) *RecommendClusterMigrationParams {
+	o.SetMigrationRequestBody(migrationRequestBody)
+	return o
+}
+
+// SetMigrationRequestBody adds the migrationRequestBody to the recommend cluster migration params
+func (o *RecommendClusterMigrationParams) SetMigrationRequestBody(migrationRequestBody *models.ClusterMigrationReq) {
+	o.MigrationRequestBody = migrationRequestBody
+}
+
+// WithProvider adds the provider to the recommend cluster migration params
+func (o *RecommendClusterMigrationParams) WithProvider(provider string) *RecommendClusterMigrationParams {
+	o.SetProvider(provider)
+	return o
+}
+
+// SetProvider adds the provider to the recommend cluster migration params
+func (o *RecommendClusterMigrationParams) SetProvider(provider string) {
+	o.Provider = provider
+}
+
+// WithRegion adds the region to the recommend cluster migration params
+func (o *RecommendClusterMigrationParams) WithRegion(region string) *RecommendClusterMigrationParams {
+	o.SetRegion(region)
+	return o
+}
+
+// SetRegion adds the region to the recommend cluster migration params
+func (o *RecommendClusterMigrationParams) SetRegion(region string) {
+	o.Region = region
+}
+
+// WithService adds the service to the recommend cluster migration params
+func (o *RecommendClusterMigrationParams) WithService(service string) *RecommendClusterMigrationParams {
+	o.SetService(service)
+	return o
+}
+
+// SetService adds the service to the recommend cluster migration params
+func (o *RecommendClusterMigrationParams) SetService(service string) {
+	o.Service = service
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *RecommendClusterMigrationParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if o.MigrationRequestBody != nil {
+		if err := r.SetBodyParam(o.MigrationRequestBody); err != nil {
+			return err
+		}
+	}
+
+	// path param provider
+	if err := r.SetPathParam("provider", o.Provider); err != nil {
+		return err
+	}
+
+	// path param region
+	if err := r.SetPathParam("region", o.Region); err != nil {
+		return err
+	}
+
+	// path param service
+	if err := r.SetPathParam("service", o.Service); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package recommend
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	models "github.com/banzaicloud/telescopes/pkg/recommender-client/models"
+)
+
+// RecommendClusterMigrationReader is a Reader for the RecommendClusterMigration structure.
+type RecommendClusterMigrationReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *RecommendClusterMigrationReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+
+	case 200:
+		result := NewRecommendClusterMigrationOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewRecommendClusterMigrationOK creates a RecommendClusterMigrationOK with default headers values
+func NewRecommendClusterMigrationOK() *RecommendClusterMigrationOK {
+	return &RecommendClusterMigrationOK{}
+}
+
+/*
+RecommendClusterMigrationOK handles this case with default header values.
+
+migration response
+*/
+type RecommendClusterMigrationOK struct {
+	Payload *models.MigrationResponse
+}
+
+func (o *RecommendClusterMigrationOK) Error() string {
+	return fmt.Sprintf("[POST /recommender/provider/{provider}/service/{service}/region/{region}/migration][%d] recommendClusterMigrationOK  %+v", 200, o.Payload)
+}
+
+func (o *RecommendClusterMigrationOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.MigrationResponse)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
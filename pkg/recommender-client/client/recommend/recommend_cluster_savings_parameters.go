@@ -0,0 +1,203 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package recommend
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	models "github.com/banzaicloud/telescopes/pkg/recommender-client/models"
+)
+
+// NewRecommendClusterSavingsParams creates a new RecommendClusterSavingsParams object
+// with the default values initialized.
+func NewRecommendClusterSavingsParams() *RecommendClusterSavingsParams {
+	var ()
+	return &RecommendClusterSavingsParams{
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewRecommendClusterSavingsParamsWithTimeout creates a new RecommendClusterSavingsParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewRecommendClusterSavingsParamsWithTimeout(timeout time.Duration) *RecommendClusterSavingsParams {
+	var ()
+	return &RecommendClusterSavingsParams{
+
+		timeout: timeout,
+	}
+}
+
+// NewRecommendClusterSavingsParamsWithContext creates a new RecommendClusterSavingsParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewRecommendClusterSavingsParamsWithContext(ctx context.Context) *RecommendClusterSavingsParams {
+	var ()
+	return &RecommendClusterSavingsParams{
+
+		Context: ctx,
+	}
+}
+
+// NewRecommendClusterSavingsParamsWithHTTPClient creates a new RecommendClusterSavingsParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewRecommendClusterSavingsParamsWithHTTPClient(client *http.Client) *RecommendClusterSavingsParams {
+	var ()
+	return &RecommendClusterSavingsParams{
+		HTTPClient: client,
+	}
+}
+
+/*
+RecommendClusterSavingsParams contains all the parameters to send to the API endpoint
+for the recommend cluster savings operation typically these are written to a http.Request
+*/
+type RecommendClusterSavingsParams struct {
+
+	/*Provider
+	  provider
+
+	*/
+	Provider string
+	/*Region
+	  region
+
+	*/
+	Region string
+	/*SavingsRequestBody
+	  request params
+
+	*/
+	SavingsRequestBody *models.ClusterSavingsReq
+	/*Service
+	  service
+
+	*/
+	Service string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the recommend cluster savings params
+func (o *RecommendClusterSavingsParams) WithTimeout(timeout time.Duration) *RecommendClusterSavingsParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the recommend cluster savings params
+func (o *RecommendClusterSavingsParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the recommend cluster savings params
+func (o *RecommendClusterSavingsParams) WithContext(ctx context.Context) *RecommendClusterSavingsParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the recommend cluster savings params
+func (o *RecommendClusterSavingsParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the recommend cluster savings params
+func (o *RecommendClusterSavingsParams) WithHTTPClient(client *http.Client) *RecommendClusterSavingsParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the recommend cluster savings params
+func (o *RecommendClusterSavingsParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithProvider adds the provider to the recommend cluster savings params
+func (o *RecommendClusterSavingsParams) WithProvider(provider string) *RecommendClusterSavingsParams {
+	o.SetProvider(provider)
+	return o
+}
+
+// SetProvider adds the provider to the recommend cluster savings params
+func (o *RecommendClusterSavingsParams) SetProvider(provider string) {
+	o.Provider = provider
+}
+
+// WithRegion adds the region to the recommend cluster savings params
+func (o *RecommendClusterSavingsParams) WithRegion(region string) *RecommendClusterSavingsParams {
+	o.SetRegion(region)
+	return o
+}
+
+// SetRegion adds the region to the recommend cluster savings params
+func (o *RecommendClusterSavingsParams) SetRegion(region string) {
+	o.Region = region
+}
+
+// WithSavingsRequestBody adds the savingsRequestBody to the recommend cluster savings params
+func (o *RecommendClusterSavingsParams) WithSavingsRequestBody(savingsRequestBody *models.ClusterSavingsReq) *RecommendClusterSavingsParams {
+	o.SetSavingsRequestBody(savingsRequestBody)
+	return o
+}
+
+// SetSavingsRequestBody adds the savingsRequestBody to the recommend cluster savings params
+func (o *RecommendClusterSavingsParams) SetSavingsRequestBody(savingsRequestBody *models.ClusterSavingsReq) {
+	o.SavingsRequestBody = savingsRequestBody
+}
+
+// WithService adds the service to the recommend cluster savings params
+func (o *RecommendClusterSavingsParams) WithService(service string) *RecommendClusterSavingsParams {
+	o.SetService(service)
+	return o
+}
+
+// SetService adds the service to the recommend cluster savings params
+func (o *RecommendClusterSavingsParams) SetService(service string) {
+	o.Service = service
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *RecommendClusterSavingsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	// path param provider
+	if err := r.SetPathParam("provider", o.Provider); err != nil {
+		return err
+	}
+
+	// path param region
+	if err := r.SetPathParam("region", o.Region); err != nil {
+		return err
+	}
+
+	if o.SavingsRequestBody != nil {
+		if err := r.SetBodyParam(o.SavingsRequestBody); err != nil {
+			return err
+		}
+	}
+
+	// path param service
+	if err := r.SetPathParam("service", o.Service); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package recommend
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	models "github.com/banzaicloud/telescopes/pkg/recommender-client/models"
+)
+
+// RecommendClusterSavingsReader is a Reader for the RecommendClusterSavings structure.
+type RecommendClusterSavingsReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *RecommendClusterSavingsReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+
+	case 200:
+		result := NewRecommendClusterSavingsOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewRecommendClusterSavingsOK creates a RecommendClusterSavingsOK with default headers values
+func NewRecommendClusterSavingsOK() *RecommendClusterSavingsOK {
+	return &RecommendClusterSavingsOK{}
+}
+
+/*
+RecommendClusterSavingsOK handles this case with default header values.
+
+savings response
+*/
+type RecommendClusterSavingsOK struct {
+	Payload *models.SavingsResponse
+}
+
+func (o *RecommendClusterSavingsOK) Error() string {
+	return fmt.Sprintf("[POST /recommender/provider/{provider}/service/{service}/region/{region}/savings][%d] recommendClusterSavingsOK  %+v", 200, o.Payload)
+}
+
+func (o *RecommendClusterSavingsOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.SavingsResponse)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -6,15 +6,13 @@ package recommend
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"context"
 	"net/http"
 	"time"
 
-	"golang.org/x/net/context"
-
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
-	"github.com/go-openapi/swag"
 
 	strfmt "github.com/go-openapi/strfmt"
 
@@ -66,47 +64,26 @@ for the recommend cluster scale out operation typically these are written to a h
 */
 type RecommendClusterScaleOutParams struct {
 
-	/*ActualLayout
-	  Description of the current cluster layout
-
-	*/
-	ActualLayout []*models.NodePoolDesc
-	/*DesiredCPU
-	  Total desired number of CPUs in the cluster after the scale out
-
-	*/
-	DesiredCPU *float64
-	/*DesiredGpu
-	  Total desired number of GPUs in the cluster after the scale out
-
-	*/
-	DesiredGpu *int64
-	/*DesiredMem
-	  Total desired memory (GB) in the cluster after the scale out
+	/*Provider
+	  provider
 
 	*/
-	DesiredMem *float64
-	/*Excludes
-	  Excludes is a blacklist - a slice with vm types to be excluded from the recommendation
+	Provider string
+	/*RecommendRequestBody
+	  request params
 
 	*/
-	Excludes []string
-	/*OnDemandPct
-	  Percentage of regular (on-demand) nodes among the scale out nodes
+	RecommendRequestBody *models.ClusterScaleoutRecommendationReq
+	/*Region
+	  region
 
 	*/
-	OnDemandPct *int64
-	/*Provider*/
-	Provider string
-	/*Region*/
 	Region string
-	/*Service*/
-	Service string
-	/*Zone
-	  Availability zone to be included in the recommendation
+	/*Service
+	  service
 
 	*/
-	Zone *string
+	Service string
 
 	timeout    time.Duration
 	Context    context.Context
@@ -146,72 +123,6 @@ func (o *RecommendClusterScaleOutParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
-// WithActualLayout adds the actualLayout to the recommend cluster scale out params
-func (o *RecommendClusterScaleOutParams) WithActualLayout(actualLayout []*models.NodePoolDesc) *RecommendClusterScaleOutParams {
-	o.SetActualLayout(actualLayout)
-	return o
-}
-
-// SetActualLayout adds the actualLayout to the recommend cluster scale out params
-func (o *RecommendClusterScaleOutParams) SetActualLayout(actualLayout []*models.NodePoolDesc) {
-	o.ActualLayout = actualLayout
-}
-
-// WithDesiredCPU adds the desiredCPU to the recommend cluster scale out params
-func (o *RecommendClusterScaleOutParams) WithDesiredCPU(desiredCPU *float64) *RecommendClusterScaleOutParams {
-	o.SetDesiredCPU(desiredCPU)
-	return o
-}
-
-// SetDesiredCPU adds the desiredCpu to the recommend cluster scale out params
-func (o *RecommendClusterScaleOutParams) SetDesiredCPU(desiredCPU *float64) {
-	o.DesiredCPU = desiredCPU
-}
-
-// WithDesiredGpu adds the desiredGpu to the recommend cluster scale out params
-func (o *RecommendClusterScaleOutParams) WithDesiredGpu(desiredGpu *int64) *RecommendClusterScaleOutParams {
-	o.SetDesiredGpu(desiredGpu)
-	return o
-}
-
-// SetDesiredGpu adds the desiredGpu to the recommend cluster scale out params
-func (o *RecommendClusterScaleOutParams) SetDesiredGpu(desiredGpu *int64) {
-	o.DesiredGpu = desiredGpu
-}
-
-// WithDesiredMem adds the desiredMem to the recommend cluster scale out params
-func (o *RecommendClusterScaleOutParams) WithDesiredMem(desiredMem *float64) *RecommendClusterScaleOutParams {
-	o.SetDesiredMem(desiredMem)
-	return o
-}
-
-// SetDesiredMem adds the desiredMem to the recommend cluster scale out params
-func (o *RecommendClusterScaleOutParams) SetDesiredMem(desiredMem *float64) {
-	o.DesiredMem = desiredMem
-}
-
-// WithExcludes adds the excludes to the recommend cluster scale out params
-func (o *RecommendClusterScaleOutParams) WithExcludes(excludes []string) *RecommendClusterScaleOutParams {
-	o.SetExcludes(excludes)
-	return o
-}
-
-// SetExcludes adds the excludes to the recommend cluster scale out params
-func (o *RecommendClusterScaleOutParams) SetExcludes(excludes []string) {
-	o.Excludes = excludes
-}
-
-// WithOnDemandPct adds the onDemandPct to the recommend cluster scale out params
-func (o *RecommendClusterScaleOutParams) WithOnDemandPct(onDemandPct *int64) *RecommendClusterScaleOutParams {
-	o.SetOnDemandPct(onDemandPct)
-	return o
-}
-
-// SetOnDemandPct adds the onDemandPct to the recommend cluster scale out params
-func (o *RecommendClusterScaleOutParams) SetOnDemandPct(onDemandPct *int64) {
-	o.OnDemandPct = onDemandPct
-}
-
 // WithProvider adds the provider to the recommend cluster scale out params
 func (o *RecommendClusterScaleOutParams) WithProvider(provider string) *RecommendClusterScaleOutParams {
 	o.SetProvider(provider)
@@ -223,6 +134,17 @@ func (o *RecommendClusterScaleOutParams) SetProvider(provider string) {
 	o.Provider = provider
 }
 
+// WithRecommendRequestBody adds the recommendRequestBody to the recommend cluster scale out params
+func (o *RecommendClusterScaleOutParams) WithRecommendRequestBody(recommendRequestBody *models.ClusterScaleoutRecommendationReq) *RecommendClusterScaleOutParams {
+	o.SetRecommendRequestBody(recommendRequestBody)
+	return o
+}
+
+// SetRecommendRequestBody adds the recommendRequestBody to the recommend cluster scale out params
+func (o *RecommendClusterScaleOutParams) SetRecommendRequestBody(recommendRequestBody *models.ClusterScaleoutRecommendationReq) {
+	o.RecommendRequestBody = recommendRequestBody
+}
+
 // WithRegion adds the region to the recommend cluster scale out params
 func (o *RecommendClusterScaleOutParams) WithRegion(region string) *RecommendClusterScaleOutParams {
 	o.SetRegion(region)
@@ -245,17 +167,6 @@ func (o *RecommendClusterScaleOutParams) SetService(service string) {
 	o.Service = service
 }
 
-// WithZone adds the zone to the recommend cluster scale out params
-func (o *RecommendClusterScaleOutParams) WithZone(zone *string) *RecommendClusterScaleOutParams {
-	o.SetZone(zone)
-	return o
-}
-
-// SetZone adds the zone to the recommend cluster scale out params
-func (o *RecommendClusterScaleOutParams) SetZone(zone *string) {
-	o.Zone = zone
-}
-
 // WriteToRequest writes these params to a swagger request
 func (o *RecommendClusterScaleOutParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -264,87 +175,15 @@ func (o *RecommendClusterScaleOutParams) WriteToRequest(r runtime.ClientRequest,
 	}
 	var res []error
 
-	if o.ActualLayout != nil {
-		if err := r.SetBodyParam(o.ActualLayout); err != nil {
-			return err
-		}
-	}
-
-	if o.DesiredCPU != nil {
-
-		// query param desiredCpu
-		var qrDesiredCPU float64
-		if o.DesiredCPU != nil {
-			qrDesiredCPU = *o.DesiredCPU
-		}
-		qDesiredCPU := swag.FormatFloat64(qrDesiredCPU)
-		if qDesiredCPU != "" {
-			if err := r.SetQueryParam("desiredCpu", qDesiredCPU); err != nil {
-				return err
-			}
-		}
-
-	}
-
-	if o.DesiredGpu != nil {
-
-		// query param desiredGpu
-		var qrDesiredGpu int64
-		if o.DesiredGpu != nil {
-			qrDesiredGpu = *o.DesiredGpu
-		}
-		qDesiredGpu := swag.FormatInt64(qrDesiredGpu)
-		if qDesiredGpu != "" {
-			if err := r.SetQueryParam("desiredGpu", qDesiredGpu); err != nil {
-				return err
-			}
-		}
-
-	}
-
-	if o.DesiredMem != nil {
-
-		// query param desiredMem
-		var qrDesiredMem float64
-		if o.DesiredMem != nil {
-			qrDesiredMem = *o.DesiredMem
-		}
-		qDesiredMem := swag.FormatFloat64(qrDesiredMem)
-		if qDesiredMem != "" {
-			if err := r.SetQueryParam("desiredMem", qDesiredMem); err != nil {
-				return err
-			}
-		}
-
-	}
-
-	valuesExcludes := o.Excludes
-
-	joinedExcludes := swag.JoinByFormat(valuesExcludes, "")
-	// query array param excludes
-	if err := r.SetQueryParam("excludes", joinedExcludes...); err != nil {
+	// path param provider
+	if err := r.SetPathParam("provider", o.Provider); err != nil {
 		return err
 	}
 
-	if o.OnDemandPct != nil {
-
-		// query param onDemandPct
-		var qrOnDemandPct int64
-		if o.OnDemandPct != nil {
-			qrOnDemandPct = *o.OnDemandPct
-		}
-		qOnDemandPct := swag.FormatInt64(qrOnDemandPct)
-		if qOnDemandPct != "" {
-			if err := r.SetQueryParam("onDemandPct", qOnDemandPct); err != nil {
-				return err
-			}
+	if o.RecommendRequestBody != nil {
+		if err := r.SetBodyParam(o.RecommendRequestBody); err != nil {
+			return err
 		}
-
-	}
-
-	// path param provider
-	if err := r.SetPathParam("provider", o.Provider); err != nil {
-		return err
 	}
 
 	// path param region
@@ -357,22 +196,6 @@ func (o *RecommendClusterScaleOutParams) WriteToRequest(r runtime.ClientRequest,
 		return err
 	}
 
-	if o.Zone != nil {
-
-		// query param zone
-		var qrZone string
-		if o.Zone != nil {
-			qrZone = *o.Zone
-		}
-		qZone := qrZone
-		if qZone != "" {
-			if err := r.SetQueryParam("zone", qZone); err != nil {
-				return err
-			}
-		}
-
-	}
-
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
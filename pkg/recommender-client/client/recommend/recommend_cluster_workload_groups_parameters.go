@@ -0,0 +1,203 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package recommend
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	models "github.com/banzaicloud/telescopes/pkg/recommender-client/models"
+)
+
+// NewRecommendClusterWorkloadGroupsParams creates a new RecommendClusterWorkloadGroupsParams object
+// with the default values initialized.
+func NewRecommendClusterWorkloadGroupsParams() *RecommendClusterWorkloadGroupsParams {
+	var ()
+	return &RecommendClusterWorkloadGroupsParams{
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewRecommendClusterWorkloadGroupsParamsWithTimeout creates a new RecommendClusterWorkloadGroupsParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewRecommendClusterWorkloadGroupsParamsWithTimeout(timeout time.Duration) *RecommendClusterWorkloadGroupsParams {
+	var ()
+	return &RecommendClusterWorkloadGroupsParams{
+
+		timeout: timeout,
+	}
+}
+
+// NewRecommendClusterWorkloadGroupsParamsWithContext creates a new RecommendClusterWorkloadGroupsParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewRecommendClusterWorkloadGroupsParamsWithContext(ctx context.Context) *RecommendClusterWorkloadGroupsParams {
+	var ()
+	return &RecommendClusterWorkloadGroupsParams{
+
+		Context: ctx,
+	}
+}
+
+// NewRecommendClusterWorkloadGroupsParamsWithHTTPClient creates a new RecommendClusterWorkloadGroupsParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewRecommendClusterWorkloadGroupsParamsWithHTTPClient(client *http.Client) *RecommendClusterWorkloadGroupsParams {
+	var ()
+	return &RecommendClusterWorkloadGroupsParams{
+		HTTPClient: client,
+	}
+}
+
+/*
+RecommendClusterWorkloadGroupsParams contains all the parameters to send to the API endpoint
+for the recommend cluster workload groups operation typically these are written to a http.Request
+*/
+type RecommendClusterWorkloadGroupsParams struct {
+
+	/*Provider
+	  provider
+
+	*/
+	Provider string
+	/*Region
+	  region
+
+	*/
+	Region string
+	/*Service
+	  service
+
+	*/
+	Service string
+	/*WorkloadGroupsRequestBody
+	  request params
+
+	*/
+	WorkloadGroupsRequestBody *models.ClusterWorkloadGroupsReq
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the recommend cluster workload groups params
+func (o *RecommendClusterWorkloadGroupsParams) WithTimeout(timeout time.Duration) *RecommendClusterWorkloadGroupsParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the recommend cluster workload groups params
+func (o *RecommendClusterWorkloadGroupsParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the recommend cluster workload groups params
+func (o *RecommendClusterWorkloadGroupsParams) WithContext(ctx context.Context) *RecommendClusterWorkloadGroupsParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the recommend cluster workload groups params
+func (o *RecommendClusterWorkloadGroupsParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the recommend cluster workload groups params
+func (o *RecommendClusterWorkloadGroupsParams) WithHTTPClient(client *http.Client) *RecommendClusterWorkloadGroupsParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the recommend cluster workload groups params
+func (o *RecommendClusterWorkloadGroupsParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithProvider adds the provider to the recommend cluster workload groups params
+func (o *RecommendClusterWorkloadGroupsParams) WithProvider(provider string) *RecommendClusterWorkloadGroupsParams {
+	o.SetProvider(provider)
+	return o
+}
+
+// SetProvider adds the provider to the recommend cluster workload groups params
+func (o *RecommendClusterWorkloadGroupsParams) SetProvider(provider string) {
+	o.Provider = provider
+}
+
+// WithRegion adds the region to the recommend cluster workload groups params
+func (o *RecommendClusterWorkloadGroupsParams) WithRegion(region string) *RecommendClusterWorkloadGroupsParams {
+	o.SetRegion(region)
+	return o
+}
+
+// SetRegion adds the region to the recommend cluster workload groups params
+func (o *RecommendClusterWorkloadGroupsParams) SetRegion(region string) {
+	o.Region = region
+}
+
+// WithService adds the service to the recommend cluster workload groups params
+func (o *RecommendClusterWorkloadGroupsParams) WithService(service string) *RecommendClusterWorkloadGroupsParams {
+	o.SetService(service)
+	return o
+}
+
+// SetService adds the service to the recommend cluster workload groups params
+func (o *RecommendClusterWorkloadGroupsParams) SetService(service string) {
+	o.Service = service
+}
+
+// WithWorkloadGroupsRequestBody adds the workloadGroupsRequestBody to the recommend cluster workload groups params
+func (o *RecommendClusterWorkloadGroupsParams) WithWorkloadGroupsRequestBody(workloadGroupsRequestBody *models.ClusterWorkloadGroupsReq) *RecommendClusterWorkloadGroupsParams {
+	o.SetWorkloadGroupsRequestBody(workloadGroupsRequestBody)
+	return o
+}
+
+// SetWorkloadGroupsRequestBody adds the workloadGroupsRequestBody to the recommend cluster workload groups params
+func (o *RecommendClusterWorkloadGroupsParams) SetWorkloadGroupsRequestBody(workloadGroupsRequestBody *models.ClusterWorkloadGroupsReq) {
+	o.WorkloadGroupsRequestBody = workloadGroupsRequestBody
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *RecommendClusterWorkloadGroupsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	// path param provider
+	if err := r.SetPathParam("provider", o.Provider); err != nil {
+		return err
+	}
+
+	// path param region
+	if err := r.SetPathParam("region", o.Region); err != nil {
+		return err
+	}
+
+	// path param service
+	if err := r.SetPathParam("service", o.Service); err != nil {
+		return err
+	}
+
+	if o.WorkloadGroupsRequestBody != nil {
+		if err := r.SetBodyParam(o.WorkloadGroupsRequestBody); err != nil {
+			return err
+		}
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package recommend
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	models "github.com/banzaicloud/telescopes/pkg/recommender-client/models"
+)
+
+// RecommendClusterWorkloadGroupsReader is a Reader for the RecommendClusterWorkloadGroups structure.
+type RecommendClusterWorkloadGroupsReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *RecommendClusterWorkloadGroupsReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+
+	case 200:
+		result := NewRecommendClusterWorkloadGroupsOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewRecommendClusterWorkloadGroupsOK creates a RecommendClusterWorkloadGroupsOK with default headers values
+func NewRecommendClusterWorkloadGroupsOK() *RecommendClusterWorkloadGroupsOK {
+	return &RecommendClusterWorkloadGroupsOK{}
+}
+
+/*
+RecommendClusterWorkloadGroupsOK handles this case with default header values.
+
+workload groups response
+*/
+type RecommendClusterWorkloadGroupsOK struct {
+	Payload *models.WorkloadGroupsResponse
+}
+
+func (o *RecommendClusterWorkloadGroupsOK) Error() string {
+	return fmt.Sprintf("[POST /recommender/provider/{provider}/service/{service}/region/{region}/groups][%d] recommendClusterWorkloadGroupsOK  %+v", 200, o.Payload)
+}
+
+func (o *RecommendClusterWorkloadGroupsOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.WorkloadGroupsResponse)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -6,17 +6,17 @@ package recommend
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"context"
 	"net/http"
 	"time"
 
-	"golang.org/x/net/context"
-
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
-	"github.com/go-openapi/swag"
 
 	strfmt "github.com/go-openapi/strfmt"
+
+	models "github.com/banzaicloud/telescopes/pkg/recommender-client/models"
 )
 
 // NewRecommendMultiClusterParams creates a new RecommendMultiClusterParams object
@@ -64,10 +64,11 @@ for the recommend multi cluster operation typically these are written to a http.
 */
 type RecommendMultiClusterParams struct {
 
-	/*Provider*/
-	Provider *string
-	/*Services*/
-	Services []string
+	/*RecommendRequestBody
+	  request params
+
+	*/
+	RecommendRequestBody *models.MultiClusterRecommendationReq
 
 	timeout    time.Duration
 	Context    context.Context
@@ -107,26 +108,15 @@ func (o *RecommendMultiClusterParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
-// WithProvider adds the provider to the recommend multi cluster params
-func (o *RecommendMultiClusterParams) WithProvider(provider *string) *RecommendMultiClusterParams {
-	o.SetProvider(provider)
-	return o
-}
-
-// SetProvider adds the provider to the recommend multi cluster params
-func (o *RecommendMultiClusterParams) SetProvider(provider *string) {
-	o.Provider = provider
-}
-
-// WithServices adds the services to the recommend multi cluster params
-func (o *RecommendMultiClusterParams) WithServices(services []string) *RecommendMultiClusterParams {
-	o.SetServices(services)
+// WithRecommendRequestBody adds the recommendRequestBody to the recommend multi cluster params
+func (o *RecommendMultiClusterParams) WithRecommendRequestBody(recommendRequestBody *models.MultiClusterRecommendationReq) *RecommendMultiClusterParams {
+	o.SetRecommendRequestBody(recommendRequestBody)
 	return o
 }
 
-// SetServices adds the services to the recommend multi cluster params
-func (o *RecommendMultiClusterParams) SetServices(services []string) {
-	o.Services = services
+// SetRecommendRequestBody adds the recommendRequestBody to the recommend multi cluster params
+func (o *RecommendMultiClusterParams) SetRecommendRequestBody(recommendRequestBody *models.MultiClusterRecommendationReq) {
+	o.RecommendRequestBody = recommendRequestBody
 }
 
 // WriteToRequest writes these params to a swagger request
@@ -137,28 +127,10 @@ func (o *RecommendMultiClusterParams) WriteToRequest(r runtime.ClientRequest, re
 	}
 	var res []error
 
-	if o.Provider != nil {
-
-		// query param provider
-		var qrProvider string
-		if o.Provider != nil {
-			qrProvider = *o.Provider
-		}
-		qProvider := qrProvider
-		if qProvider != "" {
-			if err := r.SetQueryParam("provider", qProvider); err != nil {
-				return err
-			}
+	if o.RecommendRequestBody != nil {
+		if err := r.SetBodyParam(o.RecommendRequestBody); err != nil {
+			return err
 		}
-
-	}
-
-	valuesServices := o.Services
-
-	joinedServices := swag.JoinByFormat(valuesServices, "")
-	// query array param services
-	if err := r.SetQueryParam("services", joinedServices...); err != nil {
-		return err
 	}
 
 	if len(res) > 0 {
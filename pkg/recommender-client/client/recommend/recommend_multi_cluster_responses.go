@@ -45,10 +45,10 @@ func NewRecommendMultiClusterOK() *RecommendMultiClusterOK {
 /*
 RecommendMultiClusterOK handles this case with default header values.
 
-RecommendationResponse
+recommendation response
 */
 type RecommendMultiClusterOK struct {
-	Payload *models.ClusterRecommendationResp
+	Payload *models.RecommendationResponse
 }
 
 func (o *RecommendMultiClusterOK) Error() string {
@@ -57,7 +57,7 @@ func (o *RecommendMultiClusterOK) Error() string {
 
 func (o *RecommendMultiClusterOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
-	o.Payload = new(models.ClusterRecommendationResp)
+	o.Payload = new(models.RecommendationResponse)
 
 	// response payload
 	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
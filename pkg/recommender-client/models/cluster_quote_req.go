@@ -0,0 +1,87 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"strconv"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/swag"
+)
+
+// ClusterQuoteReq ClusterQuoteReq encapsulates the data of a cluster layout to be priced
+// swagger:model ClusterQuoteReq
+type ClusterQuoteReq struct {
+
+	// Description of the cluster layout to be priced
+	// in:body
+	Layout []*NodePoolDesc `json:"layout"`
+
+	// Operating system of the worker nodes: linux (default) or windows
+	Os string `json:"os,omitempty"`
+
+	// Availability zone of the cluster
+	Zone string `json:"zone,omitempty"`
+}
+
+// Validate validates this cluster quote req
+func (m *ClusterQuoteReq) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateLayout(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ClusterQuoteReq) validateLayout(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Layout) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Layout); i++ {
+		if swag.IsZero(m.Layout[i]) { // not required
+			continue
+		}
+
+		if m.Layout[i] != nil {
+			if err := m.Layout[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("layout" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *ClusterQuoteReq) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *ClusterQuoteReq) UnmarshalBinary(b []byte) error {
+	var res ClusterQuoteReq
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -6,8 +6,11 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"strconv"
+
 	strfmt "github.com/go-openapi/strfmt"
 
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/swag"
 )
 
@@ -15,30 +18,63 @@ import (
 // swagger:model ClusterRecommendationAccuracy
 type ClusterRecommendationAccuracy struct {
 
+	// Performance-normalized compute units in the recommended cluster
+	RecComputeUnits float64 `json:"computeUnits,omitempty"`
+
+	// Prices of the cluster costs besides the instances, included in the total price
+	RecCostComponents []*CostComponentPrice `json:"costComponents"`
+
+	// Amount of the cost component prices in the recommended cluster
+	RecCostComponentsPrice float64 `json:"costComponentsPrice,omitempty"`
+
 	// Number of recommended cpus
 	RecCPU float64 `json:"cpu,omitempty"`
 
+	// Number of recommended cpus as a Kubernetes quantity
+	RecCPUQuantity string `json:"cpuQuantity,omitempty"`
+
+	// Price difference between the worker node pools sized with and without the headroom
+	RecHeadroomPrice float64 `json:"headroomPrice,omitempty"`
+
+	// Amount of operating system license prices in the recommended cluster
+	RecLicensePrice float64 `json:"licensePrice,omitempty"`
+
 	// Amount of master instance type prices in the recommended cluster
 	RecMasterPrice float64 `json:"masterPrice,omitempty"`
 
 	// The summarised amount of memory in the recommended cluster
 	RecMem float64 `json:"memory,omitempty"`
 
+	// The summarised amount of memory in the recommended cluster as a Kubernetes quantity
+	RecMemQuantity string `json:"memQuantity,omitempty"`
+
 	// Number of recommended nodes
 	RecNodes int64 `json:"nodes,omitempty"`
 
+	// Number of pods the worker nodes can host
+	RecPods int64 `json:"pods,omitempty"`
+
 	// Number of regular instance type in the recommended cluster
 	RecRegularNodes int64 `json:"regularNodes,omitempty"`
 
 	// Amount of regular instance type prices in the recommended cluster
 	RecRegularPrice float64 `json:"regularPrice,omitempty"`
 
+	// Worker resources remaining after the failures the cluster was requested to survive
+	RecResilience []*ResilienceCapacity `json:"resilience"`
+
+	// Average interruption frequency (percentage) of the spot nodes with known interruption frequency
+	RecSpotInterruptionRisk float64 `json:"spotInterruptionRisk,omitempty"`
+
 	// Number of spot instance type in the recommended cluster
 	RecSpotNodes int64 `json:"spotNodes,omitempty"`
 
 	// Amount of spot instance type prices in the recommended cluster
 	RecSpotPrice float64 `json:"spotPrice,omitempty"`
 
+	// Amount of surge instance type prices in the recommended cluster
+	RecSurgePrice float64 `json:"surgePrice,omitempty"`
+
 	// Total price in the recommended cluster
 	RecTotalPrice float64 `json:"totalPrice,omitempty"`
 
@@ -51,6 +87,69 @@ type ClusterRecommendationAccuracy struct {
 
 // Validate validates this cluster recommendation accuracy
 func (m *ClusterRecommendationAccuracy) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateRecCostComponents(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateRecResilience(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ClusterRecommendationAccuracy) validateRecCostComponents(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.RecCostComponents) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.RecCostComponents); i++ {
+		if swag.IsZero(m.RecCostComponents[i]) { // not required
+			continue
+		}
+
+		if m.RecCostComponents[i] != nil {
+			if err := m.RecCostComponents[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("costComponents" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *ClusterRecommendationAccuracy) validateRecResilience(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.RecResilience) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.RecResilience); i++ {
+		if swag.IsZero(m.RecResilience[i]) { // not required
+			continue
+		}
+
+		if m.RecResilience[i] != nil {
+			if err := m.RecResilience[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("resilience" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
 	return nil
 }
 
@@ -18,6 +18,9 @@ import (
 // swagger:model ClusterRecommendationResp
 type ClusterRecommendationResp struct {
 
+	// Kubernetes version of the recommended cluster
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
 	// Recommended node pools
 	NodePools []*NodePool `json:"nodePools"`
 
@@ -30,6 +33,9 @@ type ClusterRecommendationResp struct {
 	// Provider's service
 	Service string `json:"service,omitempty"`
 
+	// Signals that the recommendation is based on outdated product details as cloud info is unavailable
+	Stale bool `json:"stale,omitempty"`
+
 	// Availability zone in the recommendation - a multi-zone recommendation means that all node pools should expand to all zones
 	Zone string `json:"zone,omitempty"`
 
@@ -22,21 +22,30 @@ type ClusterScaleoutRecommendationReq struct {
 	// in:body
 	ActualLayout []*NodePoolDesc `json:"actualLayout"`
 
-	// Total desired number of CPUs in the cluster after the scale out
+	// Total desired number of CPUs in the cluster after the scale out, required unless desiredCpuQuantity is set
 	DesiredCPU float64 `json:"desiredCpu,omitempty"`
 
+	// Total desired CPUs in the cluster after the scale out as a Kubernetes quantity, eg. 500m or 16
+	DesiredCPUQuantity string `json:"desiredCpuQuantity,omitempty"`
+
 	// Total desired number of GPUs in the cluster after the scale out
 	DesiredGpu int64 `json:"desiredGpu,omitempty"`
 
-	// Total desired memory (GB) in the cluster after the scale out
+	// Total desired memory (GB) in the cluster after the scale out, required unless desiredMemQuantity is set
 	DesiredMem float64 `json:"desiredMem,omitempty"`
 
+	// Total desired memory in the cluster after the scale out as a Kubernetes quantity, eg. 16Gi or 64G
+	DesiredMemQuantity string `json:"desiredMemQuantity,omitempty"`
+
 	// Excludes is a blacklist - a slice with vm types to be excluded from the recommendation
 	Excludes []string `json:"excludes"`
 
 	// Percentage of regular (on-demand) nodes among the scale out nodes
 	OnDemandPct int64 `json:"onDemandPct,omitempty"`
 
+	// Interpretation of the on-demand percentage: share of resources (default), cost or nodes
+	OnDemandPctMode string `json:"onDemandPctMode,omitempty"`
+
 	// Availability zone to be included in the recommendation
 	Zone string `json:"zone,omitempty"`
 }
@@ -0,0 +1,84 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"strconv"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/swag"
+)
+
+// ClusterWorkloadGroupsReq ClusterWorkloadGroupsReq encapsulates the input data for recommending dedicated node pools for workload groups
+// swagger:model ClusterWorkloadGroupsReq
+type ClusterWorkloadGroupsReq struct {
+
+	// Workload groups of the cluster, each getting its own node pools
+	// in:body
+	Groups []*WorkloadGroupReq `json:"groups"`
+
+	// Availability zone that the cluster should expand to
+	Zone string `json:"zone,omitempty"`
+}
+
+// Validate validates this cluster workload groups req
+func (m *ClusterWorkloadGroupsReq) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateGroups(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ClusterWorkloadGroupsReq) validateGroups(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Groups) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Groups); i++ {
+		if swag.IsZero(m.Groups[i]) { // not required
+			continue
+		}
+
+		if m.Groups[i] != nil {
+			if err := m.Groups[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("groups" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *ClusterWorkloadGroupsReq) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *ClusterWorkloadGroupsReq) UnmarshalBinary(b []byte) error {
+	var res ClusterWorkloadGroupsReq
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,145 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"strconv"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/swag"
+)
+
+// ClusterWorkloadGroupsResp ClusterWorkloadGroupsResp encapsulates the node pools recommended for the workload groups of a cluster
+// swagger:model ClusterWorkloadGroupsResp
+type ClusterWorkloadGroupsResp struct {
+
+	// Node pools recommended for the workload groups
+	Groups []*WorkloadGroupNodePools `json:"groups"`
+
+	// The cloud provider
+	Provider string `json:"provider,omitempty"`
+
+	// Service's region
+	Region string `json:"region,omitempty"`
+
+	// Provider's service
+	Service string `json:"service,omitempty"`
+
+	// Signals that the recommendation is based on outdated product details as cloud info is unavailable
+	Stale bool `json:"stale,omitempty"`
+
+	// Availability zone in the recommendation
+	Zone string `json:"zone,omitempty"`
+
+	// accuracy
+	Accuracy *ClusterRecommendationAccuracy `json:"accuracy,omitempty"`
+
+	// master
+	Master *NodePool `json:"master,omitempty"`
+}
+
+// Validate validates this cluster workload groups resp
+func (m *ClusterWorkloadGroupsResp) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateGroups(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateAccuracy(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateMaster(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ClusterWorkloadGroupsResp) validateGroups(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Groups) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Groups); i++ {
+		if swag.IsZero(m.Groups[i]) { // not required
+			continue
+		}
+
+		if m.Groups[i] != nil {
+			if err := m.Groups[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("groups" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *ClusterWorkloadGroupsResp) validateAccuracy(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Accuracy) { // not required
+		return nil
+	}
+
+	if m.Accuracy != nil {
+		if err := m.Accuracy.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("accuracy")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *ClusterWorkloadGroupsResp) validateMaster(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Master) { // not required
+		return nil
+	}
+
+	if m.Master != nil {
+		if err := m.Master.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("master")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *ClusterWorkloadGroupsResp) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *ClusterWorkloadGroupsResp) UnmarshalBinary(b []byte) error {
+	var res ClusterWorkloadGroupsResp
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
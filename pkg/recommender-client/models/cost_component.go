@@ -0,0 +1,49 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/swag"
+)
+
+// CostComponent CostComponent describes a cluster cost besides the instances, eg. root volumes, load balancers or NAT gateways
+// swagger:model CostComponent
+type CostComponent struct {
+
+	// Name of the cost component
+	Name string `json:"name,omitempty"`
+
+	// Hourly price per unit
+	Price float64 `json:"price,omitempty"`
+
+	// Unit the price is charged per: node, zone or cluster
+	Unit string `json:"unit,omitempty"`
+}
+
+// Validate validates this cost component
+func (m *CostComponent) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *CostComponent) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *CostComponent) UnmarshalBinary(b []byte) error {
+	var res CostComponent
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,55 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/swag"
+)
+
+// CostComponentPrice CostComponentPrice describes the price of a cost component in the cluster
+// swagger:model CostComponentPrice
+type CostComponentPrice struct {
+
+	// Name of the cost component
+	Name string `json:"name,omitempty"`
+
+	// Hourly price per unit
+	Price float64 `json:"price,omitempty"`
+
+	// Number of units in the cluster
+	Quantity int64 `json:"quantity,omitempty"`
+
+	// Hourly price of the units in the cluster
+	TotalPrice float64 `json:"totalPrice,omitempty"`
+
+	// Unit the price is charged per: node, zone or cluster
+	Unit string `json:"unit,omitempty"`
+}
+
+// Validate validates this cost component price
+func (m *CostComponentPrice) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *CostComponentPrice) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *CostComponentPrice) UnmarshalBinary(b []byte) error {
+	var res CostComponentPrice
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,64 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/swag"
+)
+
+// MigrationStep MigrationStep describes a single change of a node pool during the migration
+// swagger:model MigrationStep
+type MigrationStep struct {
+
+	// Action performed on the node pool, eg. add or drain
+	Action string `json:"action,omitempty"`
+
+	// Number of cpus in the worker node pools after the step
+	CPU float64 `json:"cpu,omitempty"`
+
+	// Instance type of VMs in the node pool
+	InstanceType string `json:"instanceType,omitempty"`
+
+	// Amount of memory in the worker node pools after the step
+	Mem float64 `json:"memory,omitempty"`
+
+	// Number of nodes added to or drained from the node pool
+	Nodes int64 `json:"nodes,omitempty"`
+
+	// Number of nodes in the node pool after the step
+	PoolNodes int64 `json:"poolNodes,omitempty"`
+
+	// Total price of the cluster after the step
+	TotalPrice float64 `json:"totalPrice,omitempty"`
+
+	// Signals that the node pool consists of regular or spot/preemptible instance types
+	VMClass string `json:"vmClass,omitempty"`
+}
+
+// Validate validates this migration step
+func (m *MigrationStep) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *MigrationStep) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *MigrationStep) UnmarshalBinary(b []byte) error {
+	var res MigrationStep
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
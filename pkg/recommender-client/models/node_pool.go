@@ -16,6 +16,12 @@ import (
 // swagger:model NodePool
 type NodePool struct {
 
+	// Node image of the requested Kubernetes version
+	Image string `json:"image,omitempty"`
+
+	// Operating system of the nodes, linux if empty
+	Os string `json:"os,omitempty"`
+
 	// Role in the cluster, eg. master or worker
 	Role string `json:"role,omitempty"`
 
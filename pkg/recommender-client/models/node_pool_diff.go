@@ -0,0 +1,61 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/swag"
+)
+
+// NodePoolDiff NodePoolDiff describes the node count change of a node pool between two layouts
+// swagger:model NodePoolDiff
+type NodePoolDiff struct {
+
+	// Number of nodes in the current layout
+	CurrentNodes int64 `json:"currentNodes,omitempty"`
+
+	// Change in the number of nodes
+	Diff int64 `json:"diff,omitempty"`
+
+	// Instance type of VMs in the node pool
+	InstanceType string `json:"instanceType,omitempty"`
+
+	// Change in the hourly price of the node pool
+	PriceDiff float64 `json:"priceDiff,omitempty"`
+
+	// Number of nodes in the recommended layout
+	RecommendedNodes int64 `json:"recommendedNodes,omitempty"`
+
+	// Role in the cluster, eg. master or worker
+	Role string `json:"role,omitempty"`
+
+	// Signals that the node pool consists of regular or spot/preemptible instance types
+	VMClass string `json:"vmClass,omitempty"`
+}
+
+// Validate validates this node pool diff
+func (m *NodePoolDiff) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *NodePoolDiff) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *NodePoolDiff) UnmarshalBinary(b []byte) error {
+	var res NodePoolDiff
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
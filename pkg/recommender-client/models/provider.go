@@ -6,15 +6,12 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
-	"strconv"
-
 	strfmt "github.com/go-openapi/strfmt"
 
-	"github.com/go-openapi/errors"
 	"github.com/go-openapi/swag"
 )
 
-// Provider Provider Provider represents a cloud provider
+// Provider provider
 // swagger:model Provider
 type Provider struct {
 
@@ -22,45 +19,11 @@ type Provider struct {
 	Provider string `json:"provider,omitempty"`
 
 	// services
-	Services []*Service `json:"services"`
+	Services []string `json:"services"`
 }
 
 // Validate validates this provider
 func (m *Provider) Validate(formats strfmt.Registry) error {
-	var res []error
-
-	if err := m.validateServices(formats); err != nil {
-		res = append(res, err)
-	}
-
-	if len(res) > 0 {
-		return errors.CompositeValidationError(res...)
-	}
-	return nil
-}
-
-func (m *Provider) validateServices(formats strfmt.Registry) error {
-
-	if swag.IsZero(m.Services) { // not required
-		return nil
-	}
-
-	for i := 0; i < len(m.Services); i++ {
-		if swag.IsZero(m.Services[i]) { // not required
-			continue
-		}
-
-		if m.Services[i] != nil {
-			if err := m.Services[i].Validate(formats); err != nil {
-				if ve, ok := err.(*errors.Validation); ok {
-					return ve.ValidateName("services" + "." + strconv.Itoa(i))
-				}
-				return err
-			}
-		}
-
-	}
-
 	return nil
 }
 
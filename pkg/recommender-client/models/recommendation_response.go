@@ -0,0 +1,123 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"strconv"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/swag"
+)
+
+// RecommendationResponse RecommendationResponse encapsulates the recommendation response
+// swagger:model RecommendationResponse
+type RecommendationResponse struct {
+
+	// Kubernetes version of the recommended cluster
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	// Recommended node pools
+	NodePools []*NodePool `json:"nodePools"`
+
+	// The cloud provider
+	Provider string `json:"provider,omitempty"`
+
+	// Service's region
+	Region string `json:"region,omitempty"`
+
+	// Provider's service
+	Service string `json:"service,omitempty"`
+
+	// Signals that the recommendation is based on outdated product details as cloud info is unavailable
+	Stale bool `json:"stale,omitempty"`
+
+	// Availability zone in the recommendation - a multi-zone recommendation means that all node pools should expand to all zones
+	Zone string `json:"zone,omitempty"`
+
+	// accuracy
+	Accuracy *ClusterRecommendationAccuracy `json:"accuracy,omitempty"`
+}
+
+// Validate validates this recommendation response
+func (m *RecommendationResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateNodePools(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateAccuracy(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *RecommendationResponse) validateNodePools(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.NodePools) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.NodePools); i++ {
+		if swag.IsZero(m.NodePools[i]) { // not required
+			continue
+		}
+
+		if m.NodePools[i] != nil {
+			if err := m.NodePools[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("nodePools" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *RecommendationResponse) validateAccuracy(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Accuracy) { // not required
+		return nil
+	}
+
+	if m.Accuracy != nil {
+		if err := m.Accuracy.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("accuracy")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *RecommendationResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *RecommendationResponse) UnmarshalBinary(b []byte) error {
+	var res RecommendationResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
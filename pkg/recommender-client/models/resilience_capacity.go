@@ -0,0 +1,52 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/swag"
+)
+
+// ResilienceCapacity ResilienceCapacity describes the worst case worker resources remaining after a failure
+// swagger:model ResilienceCapacity
+type ResilienceCapacity struct {
+
+	// Number of remaining cpus
+	CPU float64 `json:"cpu,omitempty"`
+
+	// The failure, eg. node, zone or spotPool
+	Failure string `json:"failure,omitempty"`
+
+	// Amount of remaining memory
+	Mem float64 `json:"memory,omitempty"`
+
+	// Number of remaining nodes
+	Nodes int64 `json:"nodes,omitempty"`
+}
+
+// Validate validates this resilience capacity
+func (m *ResilienceCapacity) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *ResilienceCapacity) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *ResilienceCapacity) UnmarshalBinary(b []byte) error {
+	var res ResilienceCapacity
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
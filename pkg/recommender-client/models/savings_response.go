@@ -0,0 +1,145 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"strconv"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/swag"
+)
+
+// SavingsResponse SavingsResponse encapsulates the savings response
+// swagger:model SavingsResponse
+type SavingsResponse struct {
+
+	// Monthly price of the current layout
+	CurrentMonthlyPrice float64 `json:"currentMonthlyPrice,omitempty"`
+
+	// Hourly price difference between the current and the recommended layout
+	HourlySavings float64 `json:"hourlySavings,omitempty"`
+
+	// Monthly price difference between the current and the recommended layout
+	MonthlySavings float64 `json:"monthlySavings,omitempty"`
+
+	// Node count changes per node pool
+	NodePoolDiff []*NodePoolDiff `json:"nodePoolDiff"`
+
+	// Monthly price of the recommended layout
+	RecommendedMonthlyPrice float64 `json:"recommendedMonthlyPrice,omitempty"`
+
+	// Savings in percentage of the current price
+	SavingsPct float64 `json:"savingsPct,omitempty"`
+
+	// current
+	Current *ClusterRecommendationResp `json:"current,omitempty"`
+
+	// recommended
+	Recommended *ClusterRecommendationResp `json:"recommended,omitempty"`
+}
+
+// Validate validates this savings response
+func (m *SavingsResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateNodePoolDiff(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateCurrent(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateRecommended(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *SavingsResponse) validateNodePoolDiff(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.NodePoolDiff) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.NodePoolDiff); i++ {
+		if swag.IsZero(m.NodePoolDiff[i]) { // not required
+			continue
+		}
+
+		if m.NodePoolDiff[i] != nil {
+			if err := m.NodePoolDiff[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("nodePoolDiff" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *SavingsResponse) validateCurrent(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Current) { // not required
+		return nil
+	}
+
+	if m.Current != nil {
+		if err := m.Current.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("current")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *SavingsResponse) validateRecommended(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Recommended) { // not required
+		return nil
+	}
+
+	if m.Recommended != nil {
+		if err := m.Recommended.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("recommended")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *SavingsResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *SavingsResponse) UnmarshalBinary(b []byte) error {
+	var res SavingsResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
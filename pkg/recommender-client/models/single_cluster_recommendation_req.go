@@ -24,36 +24,90 @@ type SingleClusterRecommendationReq struct {
 	// Category specifies the virtual machine category
 	Category []string `json:"category"`
 
+	// Total CPUs requested for the cluster as a Kubernetes quantity, eg. 500m or 16
+	CPUQuantity string `json:"cpuQuantity,omitempty"`
+
 	// Excludes is a blacklist - a slice with vm types to be excluded from the recommendation
 	Excludes []string `json:"excludes"`
 
+	// Percentage of resources kept as headroom for bursts on top of the requested ones
+	HeadroomPct int64 `json:"headroomPct,omitempty"`
+
 	// Includes is a whitelist - a slice with vm types to be contained in the recommendation
 	Includes []string `json:"includes"`
 
+	// Kubernetes version of the cluster; the instance types are limited to the ones having a node image of the version
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	// Required local disk type of the recommended instance types: ssd (including nvme) or nvme
+	LocalStorageType string `json:"localStorageType,omitempty"`
+
+	// Maximum interruption frequency (percentage) of the spot instance types in the recommended cluster, requires interruption frequency data of the region
+	MaxInterruptionRisk float64 `json:"maxInterruptionRisk,omitempty"`
+
 	// Maximum number of nodes in the recommended cluster
 	MaxNodes int64 `json:"maxNodes,omitempty"`
 
+	// Maximum number of pods per node configured for the cluster, overrides the provider's default limit
+	MaxPodsPerNode int64 `json:"maxPodsPerNode,omitempty"`
+
+	// Maximum share (percentage) of the spot capacity in a single instance family, spot node pools are spread across families if set
+	MaxSpotFamilyPct int64 `json:"maxSpotFamilyPct,omitempty"`
+
+	// Maximum share (percentage) of the spot capacity in a single instance generation, spot node pools are spread across families if set
+	MaxSpotGenerationPct int64 `json:"maxSpotGenerationPct,omitempty"`
+
+	// Total memory requested for the cluster as a Kubernetes quantity, eg. 16Gi or 64G
+	MemQuantity string `json:"memQuantity,omitempty"`
+
+	// Minimum local disk capacity (GB) of the recommended instance types
+	MinLocalStorage float64 `json:"minLocalStorage,omitempty"`
+
+	// Minimum network bandwidth (Gbps) of the recommended instance types
+	MinNetworkGbps float64 `json:"minNetworkGbps,omitempty"`
+
 	// Minimum number of nodes in the recommended cluster
 	MinNodes int64 `json:"minNodes,omitempty"`
 
 	// NetworkPerf specifies the network performance category
 	NetworkPerf []string `json:"networkPerf"`
 
+	// If true, the cluster is sized and the instance types are ranked by performance-normalized compute units instead of vCPUs
+	NormalizeCPU bool `json:"normalizeCpu,omitempty"`
+
 	// Percentage of regular (on-demand) nodes in the recommended cluster
 	OnDemandPct int64 `json:"onDemandPct,omitempty"`
 
+	// Interpretation of the on-demand percentage: share of resources (default), cost or nodes
+	OnDemandPctMode string `json:"onDemandPctMode,omitempty"`
+
+	// Operating system of the worker nodes: linux (default) or windows
+	Os string `json:"os,omitempty"`
+
+	// If true, spot instance types are ranked by their interruption frequency first and by their price second
+	RankByInterruptionRisk bool `json:"rankByInterruptionRisk,omitempty"`
+
+	// Failures the recommended cluster should survive while keeping the requested resources: node, zone or spotPool
+	Resilience []string `json:"resilience"`
+
 	// If true, recommended instance types will have a similar size
 	SameSize bool `json:"sameSize,omitempty"`
 
-	// Total number of CPUs requested for the cluster
+	// Total number of CPUs requested for the cluster, required unless cpuQuantity is set
 	SumCPU float64 `json:"sumCpu,omitempty"`
 
 	// Total number of GPUs requested for the cluster
 	SumGpu int64 `json:"sumGpu,omitempty"`
 
-	// Total memory requested for the cluster (GB)
+	// Total memory requested for the cluster (GB), required unless memQuantity is set
 	SumMem float64 `json:"sumMem,omitempty"`
 
+	// Total number of pods the cluster should be able to host
+	SumPods int64 `json:"sumPods,omitempty"`
+
+	// Number of extra nodes for surging during rolling node upgrades
+	SurgeNodes int64 `json:"surgeNodes,omitempty"`
+
 	// Availability zone that the cluster should expand to
 	Zone string `json:"zone,omitempty"`
 }
@@ -0,0 +1,10 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+// StaleDataSource StaleDataSource is implemented by the cloud info sources serving the last known data when cloud info is unavailable
+// swagger:model StaleDataSource
+type StaleDataSource interface{}
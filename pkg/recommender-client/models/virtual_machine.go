@@ -33,9 +33,24 @@ type VirtualMachine struct {
 	// Number of GPUs in the instance type
 	Gpus float64 `json:"gpusPerVm,omitempty"`
 
+	// InterruptionRisk holds the spot interruption frequency (percentage), nil if it's unknown
+	InterruptionRisk float64 `json:"interruptionRisk,omitempty"`
+
+	// LocalStorage holds the capacity of the local disks (GB)
+	LocalStorage float64 `json:"localStorage,omitempty"`
+
+	// LocalStorageType holds the type of the local disks: nvme, ssd or hdd
+	LocalStorageType string `json:"localStorageType,omitempty"`
+
+	// MaxPods holds the maximum number of pods on a node of the instance type, 0 if it's unknown
+	MaxPods int64 `json:"maxPods,omitempty"`
+
 	// Available memory in the instance type (GB)
 	Mem float64 `json:"memPerVm,omitempty"`
 
+	// NetworkGbps holds the network bandwidth parsed from the network performance, 0 if it's unknown
+	NetworkGbps float64 `json:"networkGbps,omitempty"`
+
 	// NetworkPerf holds the network performance
 	NetworkPerf string `json:"networkPerf,omitempty"`
 
@@ -45,9 +60,15 @@ type VirtualMachine struct {
 	// Regular price of the instance type
 	OnDemandPrice float64 `json:"onDemandPrice,omitempty"`
 
+	// PerfFactor holds the performance of the instance type's vCPU relative to a baseline vCPU, 0 if it's unknown
+	PerfFactor float64 `json:"performanceFactor,omitempty"`
+
 	// Instance type
 	Type string `json:"type,omitempty"`
 
+	// WindowsLicensePrice holds the hourly price of the Windows license on the instance type
+	WindowsLicensePrice float64 `json:"windowsLicensePrice,omitempty"`
+
 	// Zones
 	Zones []string `json:"zones"`
 }
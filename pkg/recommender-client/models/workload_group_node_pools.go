@@ -0,0 +1,143 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"strconv"
+
+	strfmt "github.com/go-openapi/strfmt"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/swag"
+)
+
+// WorkloadGroupNodePools WorkloadGroupNodePools encapsulates the node pools dedicated to a workload group
+// swagger:model WorkloadGroupNodePools
+type WorkloadGroupNodePools struct {
+
+	// Labels suggested for the node pools of the group
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Name of the workload group
+	Name string `json:"name,omitempty"`
+
+	// Recommended node pools of the group
+	NodePools []*NodePool `json:"nodePools"`
+
+	// Taints suggested for the node pools of the group
+	Taints []*Taint `json:"taints"`
+
+	// accuracy
+	Accuracy *ClusterRecommendationAccuracy `json:"accuracy,omitempty"`
+}
+
+// Validate validates this workload group node pools
+func (m *WorkloadGroupNodePools) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateNodePools(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateTaints(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateAccuracy(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *WorkloadGroupNodePools) validateNodePools(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.NodePools) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.NodePools); i++ {
+		if swag.IsZero(m.NodePools[i]) { // not required
+			continue
+		}
+
+		if m.NodePools[i] != nil {
+			if err := m.NodePools[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("nodePools" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *WorkloadGroupNodePools) validateTaints(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Taints) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Taints); i++ {
+		if swag.IsZero(m.Taints[i]) { // not required
+			continue
+		}
+
+		if m.Taints[i] != nil {
+			if err := m.Taints[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("taints" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *WorkloadGroupNodePools) validateAccuracy(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Accuracy) { // not required
+		return nil
+	}
+
+	if m.Accuracy != nil {
+		if err := m.Accuracy.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("accuracy")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *WorkloadGroupNodePools) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *WorkloadGroupNodePools) UnmarshalBinary(b []byte) error {
+	var res WorkloadGroupNodePools
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package benchmark
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/banzaicloud/telescopes/pkg/recommender"
+	"github.com/goph/emperror"
+	"github.com/pkg/errors"
+)
+
+// PerformanceFactors holds the per-vCPU performance of instance families relative to a baseline vCPU
+type PerformanceFactors struct {
+	// provider -> instance family or type -> performance factor
+	factors map[string]map[string]float64
+}
+
+// Load reads the performance factors from the benchmark table file at the given path
+func Load(path string) (*PerformanceFactors, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, emperror.Wrap(err, "failed to open benchmark table file")
+	}
+	defer f.Close()
+
+	return Parse(f)
+}
+
+// Parse reads the performance factors from a JSON benchmark table, eg. {"amazon": {"c5": 1.2, "t2": 0.7}};
+// the keys are instance families (as returned by recommender.InstanceFamily) or instance types
+func Parse(r io.Reader) (*PerformanceFactors, error) {
+	var factors map[string]map[string]float64
+	if err := json.NewDecoder(r).Decode(&factors); err != nil {
+		return nil, emperror.Wrap(err, "failed to decode benchmark table")
+	}
+
+	for provider, providerFactors := range factors {
+		for key, factor := range providerFactors {
+			if factor <= 0 {
+				return nil, errors.Errorf("invalid performance factor %v for %s on %s", factor, key, provider)
+			}
+		}
+	}
+
+	return &PerformanceFactors{factors: factors}, nil
+}
+
+// PerformanceFactor returns the performance factor of the instance type, the factor of the instance type
+// takes precedence over the factor of its family; false if it's unknown
+func (pf *PerformanceFactors) PerformanceFactor(provider, instanceType string) (float64, bool) {
+	if factor, ok := pf.factors[provider][instanceType]; ok {
+		return factor, true
+	}
+	factor, ok := pf.factors[provider][recommender.InstanceFamily(instanceType)]
+	return factor, ok
+}
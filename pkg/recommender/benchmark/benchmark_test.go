@@ -0,0 +1,102 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package benchmark
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const benchmarkJson = `{
+  "amazon": {"c5": 1.2, "t2": 0.7, "c5.large": 1.1},
+  "azure": {"Standard_A_v2": 0.5}
+}`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		provider     string
+		instanceType string
+		check        func(factor float64, ok bool, err error)
+	}{
+		{
+			name:         "family performance factor found",
+			data:         benchmarkJson,
+			provider:     "amazon",
+			instanceType: "c5.xlarge",
+			check: func(factor float64, ok bool, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.True(t, ok)
+				assert.Equal(t, 1.2, factor)
+			},
+		},
+		{
+			name:         "instance type performance factor takes precedence",
+			data:         benchmarkJson,
+			provider:     "amazon",
+			instanceType: "c5.large",
+			check: func(factor float64, ok bool, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.True(t, ok)
+				assert.Equal(t, 1.1, factor)
+			},
+		},
+		{
+			name:         "azure series performance factor found",
+			data:         benchmarkJson,
+			provider:     "azure",
+			instanceType: "Standard_A2_v2",
+			check: func(factor float64, ok bool, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.True(t, ok)
+				assert.Equal(t, 0.5, factor)
+			},
+		},
+		{
+			name:         "unknown family",
+			data:         benchmarkJson,
+			provider:     "amazon",
+			instanceType: "m5.large",
+			check: func(factor float64, ok bool, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.False(t, ok)
+			},
+		},
+		{
+			name:         "invalid performance factor",
+			data:         `{"amazon": {"c5": 0}}`,
+			provider:     "amazon",
+			instanceType: "c5.large",
+			check: func(factor float64, ok bool, err error) {
+				assert.EqualError(t, err, "invalid performance factor 0 for c5 on amazon")
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			factors, err := Parse(strings.NewReader(test.data))
+			if err != nil {
+				test.check(0, false, err)
+				return
+			}
+			factor, ok := factors.PerformanceFactor(test.provider, test.instanceType)
+			test.check(factor, ok, nil)
+		})
+	}
+}
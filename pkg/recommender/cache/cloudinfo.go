@@ -0,0 +1,418 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/banzaicloud/telescopes/.gen/cloudinfo"
+	"github.com/banzaicloud/telescopes/pkg/recommender"
+	"github.com/goph/logur"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Config holds the cache settings of the cloud info data, a zero TTL disables caching of the related calls
+type Config struct {
+	Enabled bool
+	// TTL of the product details
+	ProductsTTL time.Duration
+	// TTL of the regions, zones, images and versions
+	RegionsTTL time.Duration
+	// TTL of the continents
+	ContinentsTTL time.Duration
+	// TTL of the provider and service lookups
+	ProvidersTTL time.Duration
+	// Maximum number of cached responses, unlimited if 0
+	MaxEntries int
+	// Interval of reloading the cached responses in the background, disabled if 0
+	RefreshInterval time.Duration
+	// Services whose catalogs are loaded into the cache at startup
+	Prefetch []Catalog
+}
+
+// Catalog identifies the catalog of a provider's service: its regions, zones and products
+type Catalog struct {
+	Provider string
+	Service  string
+}
+
+// entry is a cached response; it's kept after it expires to be served when the wrapped source fails
+type entry struct {
+	value   interface{}
+	expires time.Time
+	// signals that the wrapped source failed after the entry expired
+	stale bool
+	ttl   time.Duration
+	fetch func(ctx context.Context) (interface{}, error)
+}
+
+// call is an in-flight call to the wrapped source, shared by the concurrent identical calls
+type call struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// CloudInfoSource is a CloudInfoSource caching the responses of the wrapped source;
+// concurrent identical calls are deduplicated, errors are not cached, the last good response is served if the wrapped source fails
+type CloudInfoSource struct {
+	source recommender.CloudInfoSource
+	config Config
+	log    logur.Logger
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]entry
+	calls   map[string]*call
+
+	hits   *prometheus.CounterVec
+	misses *prometheus.CounterVec
+	stale  *prometheus.CounterVec
+}
+
+// NewCloudInfoSource wraps the source with a cache
+func NewCloudInfoSource(source recommender.CloudInfoSource, config Config, log logur.Logger) *CloudInfoSource {
+	return &CloudInfoSource{
+		source:  source,
+		config:  config,
+		log:     logur.WithFields(log, map[string]interface{}{"component": "cloud-info-cache"}),
+		now:     time.Now,
+		entries: make(map[string]entry),
+		calls:   make(map[string]*call),
+		hits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "telescopes",
+			Subsystem: "cloudinfo_cache",
+			Name:      "hits_total",
+			Help:      "Number of cloud info calls served from the cache",
+		}, []string{"method"}),
+		misses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "telescopes",
+			Subsystem: "cloudinfo_cache",
+			Name:      "misses_total",
+			Help:      "Number of cloud info calls not found in the cache",
+		}, []string{"method"}),
+		stale: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "telescopes",
+			Subsystem: "cloudinfo_cache",
+			Name:      "stale_total",
+			Help:      "Number of cloud info calls served with outdated data as cloud info failed",
+		}, []string{"method"}),
+	}
+}
+
+// Describe implements the prometheus.Collector interface
+func (s *CloudInfoSource) Describe(ch chan<- *prometheus.Desc) {
+	s.hits.Describe(ch)
+	s.misses.Describe(ch)
+	s.stale.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface
+func (s *CloudInfoSource) Collect(ch chan<- prometheus.Metric) {
+	s.hits.Collect(ch)
+	s.misses.Collect(ch)
+	s.stale.Collect(ch)
+}
+
+// GetProductDetails retrieves the product details from the cache or the wrapped source
+func (s *CloudInfoSource) GetProductDetails(ctx context.Context, provider string, service string, region string) ([]recommender.VirtualMachine, error) {
+	value, err := s.get(ctx, "GetProductDetails", s.config.ProductsTTL, func(ctx context.Context) (interface{}, error) {
+		return s.source.GetProductDetails(ctx, provider, service, region)
+	}, provider, service, region)
+	if err != nil {
+		return nil, err
+	}
+	// the callers may decorate the products, they get their own copy
+	return append([]recommender.VirtualMachine(nil), value.([]recommender.VirtualMachine)...), nil
+}
+
+// GetRegions retrieves the regions from the cache or the wrapped source
+func (s *CloudInfoSource) GetRegions(ctx context.Context, provider, service string) ([]cloudinfo.Region, error) {
+	value, err := s.get(ctx, "GetRegions", s.config.RegionsTTL, func(ctx context.Context) (interface{}, error) {
+		return s.source.GetRegions(ctx, provider, service)
+	}, provider, service)
+	if err != nil {
+		return nil, err
+	}
+	return value.([]cloudinfo.Region), nil
+}
+
+// GetContinentsData retrieves the continents data from the cache or the wrapped source
+func (s *CloudInfoSource) GetContinentsData(ctx context.Context, provider, service string) ([]cloudinfo.Continent, error) {
+	value, err := s.get(ctx, "GetContinentsData", s.config.ContinentsTTL, func(ctx context.Context) (interface{}, error) {
+		return s.source.GetContinentsData(ctx, provider, service)
+	}, provider, service)
+	if err != nil {
+		return nil, err
+	}
+	return value.([]cloudinfo.Continent), nil
+}
+
+// GetZones retrieves the zones from the cache or the wrapped source
+func (s *CloudInfoSource) GetZones(ctx context.Context, provider, service, region string) ([]string, error) {
+	value, err := s.get(ctx, "GetZones", s.config.RegionsTTL, func(ctx context.Context) (interface{}, error) {
+		return s.source.GetZones(ctx, provider, service, region)
+	}, provider, service, region)
+	if err != nil {
+		return nil, err
+	}
+	return value.([]string), nil
+}
+
+// GetContinents retrieves the supported continents from the cache or the wrapped source
+func (s *CloudInfoSource) GetContinents(ctx context.Context) ([]string, error) {
+	value, err := s.get(ctx, "GetContinents", s.config.ContinentsTTL, func(ctx context.Context) (interface{}, error) {
+		return s.source.GetContinents(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]string), nil
+}
+
+// GetRegion retrieves the region from the cache or the wrapped source
+func (s *CloudInfoSource) GetRegion(ctx context.Context, provider string, service string, region string) (string, error) {
+	value, err := s.get(ctx, "GetRegion", s.config.RegionsTTL, func(ctx context.Context) (interface{}, error) {
+		return s.source.GetRegion(ctx, provider, service, region)
+	}, provider, service, region)
+	if err != nil {
+		return "", err
+	}
+	return value.(string), nil
+}
+
+// GetProvider retrieves the provider from the cache or the wrapped source
+func (s *CloudInfoSource) GetProvider(ctx context.Context, provider string) (string, error) {
+	value, err := s.get(ctx, "GetProvider", s.config.ProvidersTTL, func(ctx context.Context) (interface{}, error) {
+		return s.source.GetProvider(ctx, provider)
+	}, provider)
+	if err != nil {
+		return "", err
+	}
+	return value.(string), nil
+}
+
+// GetService retrieves the service from the cache or the wrapped source
+func (s *CloudInfoSource) GetService(ctx context.Context, provider string, service string) (string, error) {
+	value, err := s.get(ctx, "GetService", s.config.ProvidersTTL, func(ctx context.Context) (interface{}, error) {
+		return s.source.GetService(ctx, provider, service)
+	}, provider, service)
+	if err != nil {
+		return "", err
+	}
+	return value.(string), nil
+}
+
+// GetImages retrieves the images from the cache or the wrapped source
+func (s *CloudInfoSource) GetImages(ctx context.Context, provider string, service string, region string) ([]cloudinfo.Image, error) {
+	value, err := s.get(ctx, "GetImages", s.config.RegionsTTL, func(ctx context.Context) (interface{}, error) {
+		return s.source.GetImages(ctx, provider, service, region)
+	}, provider, service, region)
+	if err != nil {
+		return nil, err
+	}
+	return value.([]cloudinfo.Image), nil
+}
+
+// GetVersions retrieves the Kubernetes versions from the cache or the wrapped source
+func (s *CloudInfoSource) GetVersions(ctx context.Context, provider string, service string, region string) ([]cloudinfo.LocationVersion, error) {
+	value, err := s.get(ctx, "GetVersions", s.config.RegionsTTL, func(ctx context.Context) (interface{}, error) {
+		return s.source.GetVersions(ctx, provider, service, region)
+	}, provider, service, region)
+	if err != nil {
+		return nil, err
+	}
+	return value.([]cloudinfo.LocationVersion), nil
+}
+
+// get returns the cached response of the call or calls the wrapped source, the expired response is served if the call
+// fails while the context of the caller is still alive
+func (s *CloudInfoSource) get(ctx context.Context, method string, ttl time.Duration, fetch func(ctx context.Context) (interface{}, error), args ...string) (interface{}, error) {
+	if ttl <= 0 {
+		return fetch(ctx)
+	}
+	key := cacheKey(method, args...)
+
+	s.mu.Lock()
+	e, cached := s.entries[key]
+	s.mu.Unlock()
+	if cached && s.now().Before(e.expires) {
+		s.hits.WithLabelValues(method).Inc()
+		return e.value, nil
+	}
+	s.misses.WithLabelValues(method).Inc()
+
+	value, err := s.load(ctx, key, ttl, fetch)
+	if err != nil && cached && ctx.Err() == nil {
+		s.log.Warn("cloud info call failed, serving outdated data", map[string]interface{}{"key": key, "error": err.Error()})
+		s.stale.WithLabelValues(method).Inc()
+		s.markStale(key)
+		return e.value, nil
+	}
+	return value, err
+}
+
+// load calls the wrapped source and caches the response, joining the identical call in progress if any; the joined
+// call is retried if it was cancelled by its initiator while the context of the caller is still alive
+func (s *CloudInfoSource) load(ctx context.Context, key string, ttl time.Duration, fetch func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	for {
+		s.mu.Lock()
+		c, ok := s.calls[key]
+		if !ok {
+			break
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-c.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if recommender.ContextError(c.err) == nil || ctx.Err() != nil {
+			return c.value, c.err
+		}
+	}
+	c := &call{done: make(chan struct{})}
+	s.calls[key] = c
+	s.mu.Unlock()
+
+	c.value, c.err = fetch(ctx)
+
+	s.mu.Lock()
+	delete(s.calls, key)
+	if c.err == nil {
+		s.store(key, entry{value: c.value, expires: s.now().Add(ttl), ttl: ttl, fetch: fetch})
+	}
+	s.mu.Unlock()
+	close(c.done)
+
+	return c.value, c.err
+}
+
+// markStale flags the cached response as outdated
+func (s *CloudInfoSource) markStale(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[key]; ok {
+		e.stale = true
+		s.entries[key] = e
+	}
+}
+
+// StaleProductDetails returns true if the served product details of the region are outdated as cloud info failed
+func (s *CloudInfoSource) StaleProductDetails(provider string, service string, region string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.entries[cacheKey("GetProductDetails", provider, service, region)].stale
+}
+
+// Refresh reloads every cached response, the responses failing to reload are kept
+func (s *CloudInfoSource) Refresh() {
+	s.mu.Lock()
+	entries := make(map[string]entry, len(s.entries))
+	for key, e := range s.entries {
+		entries[key] = e
+	}
+	s.mu.Unlock()
+
+	for key, e := range entries {
+		if _, err := s.load(context.Background(), key, e.ttl, e.fetch); err != nil {
+			s.log.Warn("failed to refresh cached cloud info data", map[string]interface{}{"key": key, "error": err.Error()})
+		}
+	}
+	s.log.Debug("refreshed cached cloud info data", map[string]interface{}{"entries": len(entries)})
+}
+
+// RefreshPeriodically reloads the cached responses in every interval until the stop channel is closed
+func (s *CloudInfoSource) RefreshPeriodically(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.Refresh()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Prefetch loads the regions, zones and products of the catalogs into the cache, the failures are logged
+func (s *CloudInfoSource) Prefetch(catalogs []Catalog) {
+	ctx := context.Background()
+	for _, c := range catalogs {
+		tags := map[string]interface{}{"provider": c.Provider, "service": c.Service}
+		s.log.Info("prefetching cloud info catalog", tags)
+
+		if _, err := s.GetProvider(ctx, c.Provider); err != nil {
+			s.log.Warn(fmt.Sprintf("failed to prefetch provider: %s", err), tags)
+			continue
+		}
+		if _, err := s.GetService(ctx, c.Provider, c.Service); err != nil {
+			s.log.Warn(fmt.Sprintf("failed to prefetch service: %s", err), tags)
+			continue
+		}
+		regions, err := s.GetRegions(ctx, c.Provider, c.Service)
+		if err != nil {
+			s.log.Warn(fmt.Sprintf("failed to prefetch regions: %s", err), tags)
+			continue
+		}
+		for _, region := range regions {
+			if _, err := s.GetRegion(ctx, c.Provider, c.Service, region.Id); err != nil {
+				s.log.Warn(fmt.Sprintf("failed to prefetch region %s: %s", region.Id, err), tags)
+				continue
+			}
+			if _, err := s.GetZones(ctx, c.Provider, c.Service, region.Id); err != nil {
+				s.log.Warn(fmt.Sprintf("failed to prefetch zones of region %s: %s", region.Id, err), tags)
+			}
+			if _, err := s.GetProductDetails(ctx, c.Provider, c.Service, region.Id); err != nil {
+				s.log.Warn(fmt.Sprintf("failed to prefetch products of region %s: %s", region.Id, err), tags)
+			}
+		}
+		s.log.Info("prefetched cloud info catalog", tags)
+	}
+}
+
+// cacheKey returns the cache key of the call
+func cacheKey(method string, args ...string) string {
+	return strings.Join(append([]string{method}, args...), "/")
+}
+
+// store adds the entry to the cache, evicting the expired entries and then the ones expiring first if the cache is full
+func (s *CloudInfoSource) store(key string, e entry) {
+	if s.config.MaxEntries > 0 && len(s.entries) >= s.config.MaxEntries {
+		now := s.now()
+		for k, cached := range s.entries {
+			if !now.Before(cached.expires) {
+				delete(s.entries, k)
+			}
+		}
+		for len(s.entries) >= s.config.MaxEntries {
+			var oldest string
+			for k, cached := range s.entries {
+				if oldest == "" || cached.expires.Before(s.entries[oldest].expires) {
+					oldest = k
+				}
+			}
+			s.log.Debug("cache is full, evicting entry", map[string]interface{}{"key": oldest})
+			delete(s.entries, oldest)
+		}
+	}
+	s.entries[key] = e
+}
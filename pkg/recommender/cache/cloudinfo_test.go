@@ -0,0 +1,301 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/banzaicloud/telescopes/.gen/cloudinfo"
+	"github.com/banzaicloud/telescopes/pkg/recommender"
+	"github.com/goph/logur"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingSource counts the calls of the wrapped source
+type countingSource struct {
+	calls int32
+	// blocks the calls until closed if set
+	release chan struct{}
+	err     error
+}
+
+func (s *countingSource) call(ctx context.Context) error {
+	atomic.AddInt32(&s.calls, 1)
+	if s.release != nil {
+		select {
+		case <-s.release:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return s.err
+}
+
+func (s *countingSource) GetProductDetails(ctx context.Context, provider string, service string, region string) ([]recommender.VirtualMachine, error) {
+	return []recommender.VirtualMachine{{Type: "m5.large", Cpus: 2}}, s.call(ctx)
+}
+
+func (s *countingSource) GetRegions(ctx context.Context, provider, service string) ([]cloudinfo.Region, error) {
+	return []cloudinfo.Region{{Id: "eu-west-1"}}, s.call(ctx)
+}
+
+func (s *countingSource) GetContinentsData(ctx context.Context, provider, service string) ([]cloudinfo.Continent, error) {
+	return nil, s.call(ctx)
+}
+
+func (s *countingSource) GetZones(ctx context.Context, provider, service, region string) ([]string, error) {
+	return []string{"eu-west-1a"}, s.call(ctx)
+}
+
+func (s *countingSource) GetContinents(ctx context.Context) ([]string, error) {
+	return []string{"Europe"}, s.call(ctx)
+}
+
+func (s *countingSource) GetRegion(ctx context.Context, provider string, service string, region string) (string, error) {
+	return region, s.call(ctx)
+}
+
+func (s *countingSource) GetProvider(ctx context.Context, provider string) (string, error) {
+	return provider, s.call(ctx)
+}
+
+func (s *countingSource) GetService(ctx context.Context, provider string, service string) (string, error) {
+	return service, s.call(ctx)
+}
+
+func (s *countingSource) GetImages(ctx context.Context, provider string, service string, region string) ([]cloudinfo.Image, error) {
+	return []cloudinfo.Image{{Name: "ubuntu"}}, s.call(ctx)
+}
+
+func (s *countingSource) GetVersions(ctx context.Context, provider string, service string, region string) ([]cloudinfo.LocationVersion, error) {
+	return []cloudinfo.LocationVersion{{Location: region, Versions: []string{"1.14.1"}}}, s.call(ctx)
+}
+
+func TestCloudInfoSource_GetProductDetails(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		source *countingSource
+		check  func(cache *CloudInfoSource, source *countingSource, clock *time.Time)
+	}{
+		{
+			name:   "cached response",
+			config: Config{ProductsTTL: time.Minute},
+			source: &countingSource{},
+			check: func(cache *CloudInfoSource, source *countingSource, clock *time.Time) {
+				vms, err := cache.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+				assert.NoError(t, err)
+				vms[0].Cpus = 4
+
+				vms, err = cache.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+				assert.NoError(t, err)
+				assert.Equal(t, float64(2), vms[0].Cpus, "the cached products should not be changed by the callers")
+				assert.Equal(t, int32(1), source.calls)
+
+				_, _ = cache.GetProductDetails(context.Background(), "amazon", "compute", "eu-central-1")
+				assert.Equal(t, int32(2), source.calls)
+			},
+		},
+		{
+			name:   "expired response",
+			config: Config{ProductsTTL: time.Minute},
+			source: &countingSource{},
+			check: func(cache *CloudInfoSource, source *countingSource, clock *time.Time) {
+				_, _ = cache.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+				*clock = clock.Add(2 * time.Minute)
+				_, _ = cache.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+				assert.Equal(t, int32(2), source.calls)
+			},
+		},
+		{
+			name:   "errors are not cached",
+			config: Config{ProductsTTL: time.Minute},
+			source: &countingSource{err: errors.New("cloud info unavailable")},
+			check: func(cache *CloudInfoSource, source *countingSource, clock *time.Time) {
+				_, err := cache.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+				assert.Error(t, err)
+				_, err = cache.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+				assert.Error(t, err)
+				assert.Equal(t, int32(2), source.calls)
+			},
+		},
+		{
+			name:   "caching disabled",
+			source: &countingSource{},
+			check: func(cache *CloudInfoSource, source *countingSource, clock *time.Time) {
+				_, _ = cache.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+				_, _ = cache.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+				assert.Equal(t, int32(2), source.calls)
+			},
+		},
+		{
+			name:   "oldest entry evicted",
+			config: Config{ProductsTTL: time.Minute, MaxEntries: 1},
+			source: &countingSource{},
+			check: func(cache *CloudInfoSource, source *countingSource, clock *time.Time) {
+				_, _ = cache.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+				_, _ = cache.GetProductDetails(context.Background(), "amazon", "compute", "eu-central-1")
+				_, _ = cache.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+				assert.Equal(t, int32(3), source.calls)
+				assert.Len(t, cache.entries, 1)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			clock := time.Now()
+			cache := NewCloudInfoSource(test.source, test.config, logur.NewTestLogger())
+			cache.now = func() time.Time { return clock }
+			test.check(cache, test.source, &clock)
+		})
+	}
+}
+
+func TestCloudInfoSource_concurrentCalls(t *testing.T) {
+	source := &countingSource{release: make(chan struct{})}
+	cache := NewCloudInfoSource(source, Config{RegionsTTL: time.Minute}, logur.NewTestLogger())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			zones, err := cache.GetZones(context.Background(), "amazon", "compute", "eu-west-1")
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"eu-west-1a"}, zones)
+		}()
+	}
+	// wait until the first call reaches the source and the others join it
+	for atomic.LoadInt32(&source.calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(source.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), source.calls)
+}
+
+func TestCloudInfoSource_cancelledCall(t *testing.T) {
+	source := &countingSource{release: make(chan struct{})}
+	cache := NewCloudInfoSource(source, Config{RegionsTTL: time.Minute}, logur.NewTestLogger())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	initiated := make(chan error)
+	go func() {
+		_, err := cache.GetZones(ctx, "amazon", "compute", "eu-west-1")
+		initiated <- err
+	}()
+	for atomic.LoadInt32(&source.calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	joined := make(chan error)
+	go func() {
+		zones, err := cache.GetZones(context.Background(), "amazon", "compute", "eu-west-1")
+		assert.Equal(t, []string{"eu-west-1a"}, zones)
+		joined <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	// the joined call is retried with the context of the caller still alive
+	cancel()
+	assert.Equal(t, context.Canceled, <-initiated)
+	for atomic.LoadInt32(&source.calls) == 1 {
+		time.Sleep(time.Millisecond)
+	}
+	close(source.release)
+	assert.NoError(t, <-joined)
+	assert.Equal(t, int32(2), source.calls)
+}
+
+func TestCloudInfoSource_staleData(t *testing.T) {
+	source := &countingSource{}
+	clock := time.Now()
+	cache := NewCloudInfoSource(source, Config{ProductsTTL: time.Minute}, logur.NewTestLogger())
+	cache.now = func() time.Time { return clock }
+
+	_, err := cache.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+	assert.NoError(t, err)
+	assert.False(t, cache.StaleProductDetails("amazon", "compute", "eu-west-1"))
+
+	source.err = errors.New("cloud info unavailable")
+	clock = clock.Add(2 * time.Minute)
+	vms, err := cache.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+	assert.NoError(t, err, "the last good response should be served")
+	assert.Len(t, vms, 1)
+	assert.True(t, cache.StaleProductDetails("amazon", "compute", "eu-west-1"))
+
+	source.err = nil
+	_, err = cache.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+	assert.NoError(t, err)
+	assert.False(t, cache.StaleProductDetails("amazon", "compute", "eu-west-1"))
+}
+
+func TestCloudInfoSource_staleDataCancelledCall(t *testing.T) {
+	source := &countingSource{}
+	clock := time.Now()
+	cache := NewCloudInfoSource(source, Config{ProductsTTL: time.Minute}, logur.NewTestLogger())
+	cache.now = func() time.Time { return clock }
+
+	_, err := cache.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+	assert.NoError(t, err)
+
+	source.err = errors.New("cloud info unavailable")
+	clock = clock.Add(2 * time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = cache.GetProductDetails(ctx, "amazon", "compute", "eu-west-1")
+	assert.Error(t, err, "the outdated response should not be served to a cancelled caller")
+	assert.False(t, cache.StaleProductDetails("amazon", "compute", "eu-west-1"))
+}
+
+func TestCloudInfoSource_Refresh(t *testing.T) {
+	source := &countingSource{}
+	clock := time.Now()
+	cache := NewCloudInfoSource(source, Config{ProductsTTL: time.Minute, RegionsTTL: time.Minute}, logur.NewTestLogger())
+	cache.now = func() time.Time { return clock }
+
+	_, _ = cache.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+	_, _ = cache.GetZones(context.Background(), "amazon", "compute", "eu-west-1")
+	clock = clock.Add(50 * time.Second)
+	cache.Refresh()
+	assert.Equal(t, int32(4), source.calls)
+
+	clock = clock.Add(50 * time.Second)
+	_, _ = cache.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+	assert.Equal(t, int32(4), source.calls, "the refreshed response should not expire")
+}
+
+func TestCloudInfoSource_Prefetch(t *testing.T) {
+	source := &countingSource{}
+	cache := NewCloudInfoSource(source, Config{ProductsTTL: time.Minute, RegionsTTL: time.Minute, ProvidersTTL: time.Minute}, logur.NewTestLogger())
+
+	cache.Prefetch([]Catalog{{Provider: "amazon", Service: "compute"}})
+	calls := source.calls
+
+	_, _ = cache.GetProvider(context.Background(), "amazon")
+	_, _ = cache.GetService(context.Background(), "amazon", "compute")
+	_, _ = cache.GetRegion(context.Background(), "amazon", "compute", "eu-west-1")
+	_, _ = cache.GetZones(context.Background(), "amazon", "compute", "eu-west-1")
+	_, _ = cache.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+	assert.Equal(t, calls, source.calls, "the prefetched catalog should be served from the cache")
+}
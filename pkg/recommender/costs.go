@@ -0,0 +1,103 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import "context"
+
+// withCostComponents adds the prices of the service's cost components to the accuracy and its total price
+func (e *Engine) withCostComponents(ctx context.Context, provider, service, region, zone string, nodePools []NodePool, accuracy ClusterRecommendationAccuracy) (ClusterRecommendationAccuracy, error) {
+	costs, err := e.clusterCosts(ctx, provider, service, region, zone)
+	if err != nil {
+		return accuracy, err
+	}
+	return costs.add(nodePools, accuracy), nil
+}
+
+// clusterCosts holds the cost components of a cluster with the number of zones the cluster spans
+type clusterCosts struct {
+	service    string
+	components []CostComponent
+	zoneCount  int
+}
+
+// clusterCosts looks up the cost components of the service, the zones of the cluster are only looked up if any of
+// the components is charged per zone
+func (e *Engine) clusterCosts(ctx context.Context, provider, service, region, zone string) (clusterCosts, error) {
+	costs := clusterCosts{service: service}
+	if e.costSource == nil {
+		return costs, nil
+	}
+
+	costs.components = e.costSource.CostComponents(provider, service)
+	for _, c := range costs.components {
+		if c.Unit == CostPerZone {
+			var err error
+			if costs.zoneCount, err = e.clusterZoneCount(ctx, provider, service, region, zone); err != nil {
+				return costs, err
+			}
+			break
+		}
+	}
+	return costs, nil
+}
+
+// add adds the prices of the cost components of the node pools to the accuracy and its total price
+func (c clusterCosts) add(nodePools []NodePool, accuracy ClusterRecommendationAccuracy) ClusterRecommendationAccuracy {
+	for _, component := range c.components {
+		var quantity int
+		switch component.Unit {
+		case CostPerNode:
+			quantity = billedNodes(c.service, nodePools)
+		case CostPerZone:
+			quantity = c.zoneCount
+		case CostPerCluster:
+			quantity = 1
+		}
+
+		price := CostComponentPrice{CostComponent: component, Quantity: quantity, TotalPrice: float64(quantity) * component.Price}
+		accuracy.RecCostComponents = append(accuracy.RecCostComponents, price)
+		accuracy.RecCostComponentsPrice += price.TotalPrice
+	}
+	accuracy.RecTotalPrice += accuracy.RecCostComponentsPrice
+
+	return accuracy
+}
+
+// clusterZoneCount returns the number of zones the cluster spans: the requested zone or every zone of the region
+func (e *Engine) clusterZoneCount(ctx context.Context, provider, service, region, zone string) (int, error) {
+	if zone != "" {
+		return 1, nil
+	}
+	zones, err := e.ciSource.GetZones(ctx, provider, service, region)
+	if err != nil {
+		return 0, err
+	}
+	if len(zones) == 0 {
+		return 1, nil
+	}
+	return len(zones), nil
+}
+
+// billedNodes returns the number of nodes in the node pools, managed control planes don't count as nodes
+func billedNodes(service string, nodePools []NodePool) int {
+	var nodes int
+	for _, np := range nodePools {
+		if np.Role == Master && (service == "eks" || service == "gke") {
+			continue
+		}
+		nodes += np.SumNodes
+	}
+	return nodes
+}
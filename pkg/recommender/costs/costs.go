@@ -0,0 +1,66 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package costs
+
+import (
+	"github.com/banzaicloud/telescopes/pkg/recommender"
+	"github.com/pkg/errors"
+)
+
+// Component is a configured cluster cost besides the instances of a provider's service
+type Component struct {
+	Provider string
+	// Service of the provider, the component applies to every service of the provider if empty
+	Service string
+	Name    string
+	// Unit the price is charged per: node, zone or cluster
+	Unit string
+	// Hourly price per unit
+	Price float64
+}
+
+// Table holds the cost components per provider and service
+type Table struct {
+	components []Component
+}
+
+// NewTable creates a cost component table, returns an error if a component is invalid
+func NewTable(components []Component) (*Table, error) {
+	for _, c := range components {
+		if c.Provider == "" || c.Name == "" {
+			return nil, errors.Errorf("cost component %q requires a provider and a name", c.Name)
+		}
+		switch c.Unit {
+		case recommender.CostPerNode, recommender.CostPerZone, recommender.CostPerCluster:
+		default:
+			return nil, errors.Errorf("invalid unit %q of cost component %s", c.Unit, c.Name)
+		}
+		if c.Price < 0 {
+			return nil, errors.Errorf("invalid price %v of cost component %s", c.Price, c.Name)
+		}
+	}
+	return &Table{components: components}, nil
+}
+
+// CostComponents returns the cost components of the provider's service
+func (t *Table) CostComponents(provider, service string) []recommender.CostComponent {
+	var components []recommender.CostComponent
+	for _, c := range t.components {
+		if c.Provider == provider && (c.Service == "" || c.Service == service) {
+			components = append(components, recommender.CostComponent{Name: c.Name, Unit: c.Unit, Price: c.Price})
+		}
+	}
+	return components
+}
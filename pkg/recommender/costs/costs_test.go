@@ -0,0 +1,105 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package costs
+
+import (
+	"testing"
+
+	"github.com/banzaicloud/telescopes/pkg/recommender"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTable(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []Component
+		check      func(table *Table, err error)
+	}{
+		{
+			name: "valid components",
+			components: []Component{
+				{Provider: "amazon", Name: "root volume", Unit: recommender.CostPerNode, Price: 0.01},
+				{Provider: "amazon", Service: "eks", Name: "load balancer", Unit: recommender.CostPerCluster, Price: 0.025},
+			},
+			check: func(table *Table, err error) {
+				assert.NoError(t, err)
+				assert.NotNil(t, table)
+			},
+		},
+		{
+			name:       "invalid unit",
+			components: []Component{{Provider: "amazon", Name: "nat gateway", Unit: "region", Price: 0.045}},
+			check: func(table *Table, err error) {
+				assert.EqualError(t, err, "invalid unit \"region\" of cost component nat gateway")
+			},
+		},
+		{
+			name:       "missing provider",
+			components: []Component{{Name: "nat gateway", Unit: recommender.CostPerZone, Price: 0.045}},
+			check: func(table *Table, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			test.check(NewTable(test.components))
+		})
+	}
+}
+
+func TestTable_CostComponents(t *testing.T) {
+	table, err := NewTable([]Component{
+		{Provider: "amazon", Name: "root volume", Unit: recommender.CostPerNode, Price: 0.01},
+		{Provider: "amazon", Service: "eks", Name: "load balancer", Unit: recommender.CostPerCluster, Price: 0.025},
+		{Provider: "google", Name: "root volume", Unit: recommender.CostPerNode, Price: 0.013},
+	})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		provider string
+		service  string
+		expected []recommender.CostComponent
+	}{
+		{
+			name:     "provider and service components",
+			provider: "amazon",
+			service:  "eks",
+			expected: []recommender.CostComponent{
+				{Name: "root volume", Unit: recommender.CostPerNode, Price: 0.01},
+				{Name: "load balancer", Unit: recommender.CostPerCluster, Price: 0.025},
+			},
+		},
+		{
+			name:     "provider components only",
+			provider: "amazon",
+			service:  "compute",
+			expected: []recommender.CostComponent{{Name: "root volume", Unit: recommender.CostPerNode, Price: 0.01}},
+		},
+		{
+			name:     "no components",
+			provider: "azure",
+			service:  "aks",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, table.CostComponents(test.provider, test.service))
+		})
+	}
+}
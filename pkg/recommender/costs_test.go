@@ -0,0 +1,78 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goph/logur"
+	"github.com/stretchr/testify/assert"
+)
+
+type dummyCostComponents []CostComponent
+
+func (c dummyCostComponents) CostComponents(provider, service string) []CostComponent {
+	return c
+}
+
+func TestEngine_withCostComponents(t *testing.T) {
+	components := dummyCostComponents{
+		{Name: "root volume", Unit: CostPerNode, Price: 0.01},
+		{Name: "nat gateway", Unit: CostPerZone, Price: 0.05},
+		{Name: "load balancer", Unit: CostPerCluster, Price: 0.025},
+	}
+	nodePools := []NodePool{
+		{SumNodes: 4, Role: Worker},
+		{SumNodes: 1, Role: Master},
+	}
+	tests := []struct {
+		name    string
+		service string
+		zone    string
+		check   func(accuracy ClusterRecommendationAccuracy, err error)
+	}{
+		{
+			name:    "multi-zone cluster",
+			service: "compute",
+			check: func(accuracy ClusterRecommendationAccuracy, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 5, accuracy.RecCostComponents[0].Quantity)
+				assert.Equal(t, 3, accuracy.RecCostComponents[1].Quantity)
+				assert.Equal(t, 1, accuracy.RecCostComponents[2].Quantity)
+				assert.InDelta(t, 0.225, accuracy.RecCostComponentsPrice, 1e-9)
+				assert.InDelta(t, 1.225, accuracy.RecTotalPrice, 1e-9)
+			},
+		},
+		{
+			name:    "single zone cluster with managed control plane",
+			service: "eks",
+			zone:    "dummyZone1",
+			check: func(accuracy ClusterRecommendationAccuracy, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 4, accuracy.RecCostComponents[0].Quantity)
+				assert.Equal(t, 1, accuracy.RecCostComponents[1].Quantity)
+				assert.InDelta(t, 0.115, accuracy.RecCostComponentsPrice, 1e-9)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			engine := NewEngine(logur.NewTestLogger(), &dummyProducts{}, nil, nil, WithCostComponents(components))
+			test.check(engine.withCostComponents(context.Background(), "amazon", test.service, "dummyRegion", test.zone, nodePools, ClusterRecommendationAccuracy{RecTotalPrice: 1}))
+		})
+	}
+}
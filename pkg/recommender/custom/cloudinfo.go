@@ -0,0 +1,329 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package custom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/banzaicloud/telescopes/.gen/cloudinfo"
+	"github.com/banzaicloud/telescopes/pkg/recommender"
+	"github.com/go-openapi/runtime"
+	"github.com/goph/emperror"
+	"github.com/pkg/errors"
+)
+
+// defaultService is the service of the custom providers not listing their services
+const defaultService = "compute"
+
+// Provider is a private provider (eg. on-prem or bare-metal capacity) defined in the configuration
+type Provider struct {
+	// Name of the provider used in the requests
+	Name string
+	// Services of the provider, compute if empty
+	Services []string
+	// Regions of the provider
+	Regions []Region
+	// Machine types of the provider
+	MachineTypes []MachineType
+	// Kubernetes versions offered in every region of the provider, the first one is the default
+	KubernetesVersions []string
+}
+
+// Region is a location of a custom provider
+type Region struct {
+	Id   string
+	Name string
+	// Continent of the region, used by the multi-cluster recommendations
+	Continent string
+	Zones     []string
+}
+
+// MachineType is a machine type of a custom provider priced at an internal chargeback rate
+type MachineType struct {
+	Type     string
+	Category string
+	Cpus     float64
+	Mem      float64
+	Gpus     float64
+	// Hourly price of a machine
+	Price float64
+	// Network bandwidth of a machine in Gbps
+	NetworkGbps         float64
+	NetworkPerfCategory string
+	// Regions offering the machine type, every region of the provider if empty
+	Regions []string
+}
+
+func (mt MachineType) offeredIn(region string) bool {
+	if len(mt.Regions) == 0 {
+		return true
+	}
+	for _, r := range mt.Regions {
+		if r == region {
+			return true
+		}
+	}
+	return false
+}
+
+// CloudInfoSource is a CloudInfoSource serving the custom providers
+type CloudInfoSource struct {
+	providers map[string]Provider
+	// continents in the order of the configuration
+	continents []string
+}
+
+// NewCloudInfoSource creates a cloud info source of the custom providers
+func NewCloudInfoSource(providers []Provider) (*CloudInfoSource, error) {
+	s := &CloudInfoSource{providers: make(map[string]Provider, len(providers))}
+	for _, p := range providers {
+		if err := validate(p); err != nil {
+			return nil, emperror.With(err, "provider", p.Name)
+		}
+		if _, ok := s.providers[p.Name]; ok {
+			return nil, errors.Errorf("duplicate custom provider %q", p.Name)
+		}
+		if len(p.Services) == 0 {
+			p.Services = []string{defaultService}
+		}
+		s.providers[p.Name] = p
+
+		for _, r := range p.Regions {
+			s.continents = appendMissing(s.continents, r.Continent)
+		}
+	}
+	return s, nil
+}
+
+// Providers returns the names of the custom providers
+func (s *CloudInfoSource) Providers() []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func validate(p Provider) error {
+	if p.Name == "" {
+		return errors.New("custom provider without name")
+	}
+	if len(p.Regions) == 0 {
+		return errors.New("custom provider without regions")
+	}
+	regions := make(map[string]bool, len(p.Regions))
+	for _, r := range p.Regions {
+		if r.Id == "" || r.Continent == "" {
+			return errors.New("region id and continent are required")
+		}
+		if regions[r.Id] {
+			return errors.Errorf("duplicate region %q", r.Id)
+		}
+		regions[r.Id] = true
+	}
+	for _, mt := range p.MachineTypes {
+		if mt.Type == "" || mt.Cpus <= 0 || mt.Mem <= 0 || mt.Price < 0 {
+			return errors.Errorf("invalid machine type %q: the type, cpus, memory and a non-negative price are required", mt.Type)
+		}
+		for _, r := range mt.Regions {
+			if !regions[r] {
+				return errors.Errorf("machine type %q refers to unknown region %q", mt.Type, r)
+			}
+		}
+	}
+	return nil
+}
+
+// GetProductDetails returns the machine types offered in the region
+func (s *CloudInfoSource) GetProductDetails(ctx context.Context, provider string, service string, region string) ([]recommender.VirtualMachine, error) {
+	p, r, err := s.region(provider, service, region)
+	if err != nil {
+		return nil, err
+	}
+
+	vms := make([]recommender.VirtualMachine, 0, len(p.MachineTypes))
+	for _, mt := range p.MachineTypes {
+		if !mt.offeredIn(r.Id) {
+			continue
+		}
+		vm := recommender.VirtualMachine{
+			Category:       mt.Category,
+			Type:           mt.Type,
+			OnDemandPrice:  mt.Price,
+			Cpus:           mt.Cpus,
+			Mem:            mt.Mem,
+			Gpus:           mt.Gpus,
+			NetworkPerfCat: mt.NetworkPerfCategory,
+			NetworkGbps:    mt.NetworkGbps,
+			CurrentGen:     true,
+			Zones:          r.Zones,
+		}
+		if mt.NetworkGbps > 0 {
+			vm.NetworkPerf = fmt.Sprintf("%g Gigabit", mt.NetworkGbps)
+		}
+		vms = append(vms, vm)
+	}
+	return vms, nil
+}
+
+// GetRegions returns the regions of the provider
+func (s *CloudInfoSource) GetRegions(ctx context.Context, provider, service string) ([]cloudinfo.Region, error) {
+	p, err := s.service(provider, service)
+	if err != nil {
+		return nil, err
+	}
+
+	regions := make([]cloudinfo.Region, 0, len(p.Regions))
+	for _, r := range p.Regions {
+		regions = append(regions, cloudinfo.Region{Id: r.Id, Name: r.Name})
+	}
+	return regions, nil
+}
+
+// GetContinentsData returns the regions of the provider grouped by continents
+func (s *CloudInfoSource) GetContinentsData(ctx context.Context, provider, service string) ([]cloudinfo.Continent, error) {
+	p, err := s.service(provider, service)
+	if err != nil {
+		return nil, err
+	}
+
+	var continents []cloudinfo.Continent
+	for _, r := range p.Regions {
+		region := cloudinfo.Region{Id: r.Id, Name: r.Name}
+		found := false
+		for i := range continents {
+			if continents[i].Name == r.Continent {
+				continents[i].Regions = append(continents[i].Regions, region)
+				found = true
+			}
+		}
+		if !found {
+			continents = append(continents, cloudinfo.Continent{Name: r.Continent, Regions: []cloudinfo.Region{region}})
+		}
+	}
+	return continents, nil
+}
+
+// GetZones returns the zones of the region
+func (s *CloudInfoSource) GetZones(ctx context.Context, provider, service, region string) ([]string, error) {
+	_, r, err := s.region(provider, service, region)
+	if err != nil {
+		return nil, err
+	}
+	return r.Zones, nil
+}
+
+// GetContinents returns the continents of the regions of the custom providers
+func (s *CloudInfoSource) GetContinents(ctx context.Context) ([]string, error) {
+	return s.continents, nil
+}
+
+// GetRegion returns the name of the region
+func (s *CloudInfoSource) GetRegion(ctx context.Context, provider string, service string, region string) (string, error) {
+	_, r, err := s.region(provider, service, region)
+	if err != nil {
+		return "", err
+	}
+	if r.Name == "" {
+		return r.Id, nil
+	}
+	return r.Name, nil
+}
+
+// GetProvider checks whether the provider is a custom provider
+func (s *CloudInfoSource) GetProvider(ctx context.Context, provider string) (string, error) {
+	p, ok := s.providers[provider]
+	if !ok {
+		return "", notFound(fmt.Sprintf("provider %s", provider))
+	}
+	return p.Name, nil
+}
+
+// GetService checks whether the service is offered by the custom provider
+func (s *CloudInfoSource) GetService(ctx context.Context, provider string, service string) (string, error) {
+	if _, err := s.service(provider, service); err != nil {
+		return "", err
+	}
+	return service, nil
+}
+
+// GetImages returns no images, the machines of the custom providers are not bound to node images
+func (s *CloudInfoSource) GetImages(ctx context.Context, provider string, service string, region string) ([]cloudinfo.Image, error) {
+	if _, _, err := s.region(provider, service, region); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// GetVersions returns the Kubernetes versions of the provider in the region
+func (s *CloudInfoSource) GetVersions(ctx context.Context, provider string, service string, region string) ([]cloudinfo.LocationVersion, error) {
+	p, r, err := s.region(provider, service, region)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.KubernetesVersions) == 0 {
+		return nil, notFound(fmt.Sprintf("kubernetes versions of provider %s", provider))
+	}
+	return []cloudinfo.LocationVersion{{
+		Location: r.Id,
+		Default:  p.KubernetesVersions[0],
+		Versions: p.KubernetesVersions,
+	}}, nil
+}
+
+func (s *CloudInfoSource) service(provider, service string) (*Provider, error) {
+	p, ok := s.providers[provider]
+	if !ok {
+		return nil, notFound(fmt.Sprintf("provider %s", provider))
+	}
+	for _, svc := range p.Services {
+		if svc == service {
+			return &p, nil
+		}
+	}
+	return nil, notFound(fmt.Sprintf("service %s of provider %s", service, provider))
+}
+
+func (s *CloudInfoSource) region(provider, service, region string) (*Provider, *Region, error) {
+	p, err := s.service(provider, service)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, r := range p.Regions {
+		if r.Id == region {
+			return p, &r, nil
+		}
+	}
+	return nil, nil, notFound(fmt.Sprintf("region %s of provider %s", region, provider))
+}
+
+// notFound returns the error of the data missing from the custom providers; it's classified as the not found
+// response of the cloud info service
+func notFound(what string) error {
+	return emperror.With(runtime.NewAPIError(fmt.Sprintf("%s not found in the custom providers", what), nil, http.StatusNotFound), "cloud-info")
+}
+
+func appendMissing(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
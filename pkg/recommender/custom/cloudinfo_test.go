@@ -0,0 +1,206 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package custom
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/banzaicloud/telescopes/.gen/cloudinfo"
+	"github.com/banzaicloud/telescopes/pkg/recommender"
+	"github.com/banzaicloud/telescopes/pkg/recommender/nodepools"
+	"github.com/banzaicloud/telescopes/pkg/recommender/vms"
+	"github.com/go-openapi/runtime"
+	"github.com/goph/logur"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func onPrem() Provider {
+	return Provider{
+		Name: "onprem",
+		Regions: []Region{
+			{Id: "dc-berlin", Name: "Berlin", Continent: "Europe", Zones: []string{"rack-1", "rack-2"}},
+			{Id: "dc-virginia", Continent: "North America", Zones: []string{"rack-1"}},
+		},
+		MachineTypes: []MachineType{
+			{Type: "bm.medium", Category: "General purpose", Cpus: 16, Mem: 64, Price: 0.35, NetworkGbps: 10},
+			{Type: "bm.gpu", Category: "GPU instance", Cpus: 32, Mem: 256, Gpus: 4, Price: 2.1, Regions: []string{"dc-berlin"}},
+		},
+		KubernetesVersions: []string{"1.14.1", "1.13.5"},
+	}
+}
+
+func TestCloudInfoSource(t *testing.T) {
+	s, err := NewCloudInfoSource([]Provider{onPrem()})
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		check func()
+	}{
+		{
+			name: "provider, service and regions",
+			check: func() {
+				provider, err := s.GetProvider(ctx, "onprem")
+				assert.NoError(t, err)
+				assert.Equal(t, "onprem", provider)
+
+				service, err := s.GetService(ctx, "onprem", "compute")
+				assert.NoError(t, err)
+				assert.Equal(t, "compute", service)
+
+				region, err := s.GetRegion(ctx, "onprem", "compute", "dc-virginia")
+				assert.NoError(t, err)
+				assert.Equal(t, "dc-virginia", region)
+
+				zones, err := s.GetZones(ctx, "onprem", "compute", "dc-berlin")
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"rack-1", "rack-2"}, zones)
+			},
+		},
+		{
+			name: "continents",
+			check: func() {
+				continents, err := s.GetContinents(ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"Europe", "North America"}, continents)
+
+				data, err := s.GetContinentsData(ctx, "onprem", "compute")
+				assert.NoError(t, err)
+				assert.Equal(t, []cloudinfo.Continent{
+					{Name: "Europe", Regions: []cloudinfo.Region{{Id: "dc-berlin", Name: "Berlin"}}},
+					{Name: "North America", Regions: []cloudinfo.Region{{Id: "dc-virginia"}}},
+				}, data)
+			},
+		},
+		{
+			name: "machine types of the regions",
+			check: func() {
+				products, err := s.GetProductDetails(ctx, "onprem", "compute", "dc-berlin")
+				assert.NoError(t, err)
+				assert.Equal(t, 2, len(products))
+				assert.Equal(t, "10 Gigabit", products[0].NetworkPerf)
+				assert.Equal(t, []string{"rack-1", "rack-2"}, products[0].Zones)
+
+				products, err = s.GetProductDetails(ctx, "onprem", "compute", "dc-virginia")
+				assert.NoError(t, err)
+				assert.Equal(t, 1, len(products))
+				assert.Equal(t, "bm.medium", products[0].Type)
+			},
+		},
+		{
+			name: "images and kubernetes versions",
+			check: func() {
+				images, err := s.GetImages(ctx, "onprem", "compute", "dc-berlin")
+				assert.NoError(t, err)
+				assert.Empty(t, images)
+
+				versions, err := s.GetVersions(ctx, "onprem", "compute", "dc-berlin")
+				assert.NoError(t, err)
+				assert.Equal(t, []cloudinfo.LocationVersion{{Location: "dc-berlin", Default: "1.14.1", Versions: []string{"1.14.1", "1.13.5"}}}, versions)
+			},
+		},
+		{
+			name: "unknown provider, service and region",
+			check: func() {
+				_, err := s.GetProvider(ctx, "amazon")
+				assert.Equal(t, http.StatusNotFound, errors.Cause(err).(*runtime.APIError).Code)
+
+				_, err = s.GetService(ctx, "onprem", "eks")
+				assert.Error(t, err)
+
+				_, err = s.GetProductDetails(ctx, "onprem", "compute", "dc-paris")
+				assert.Error(t, err)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test // scopelint
+		t.Run(test.name, func(t *testing.T) {
+			test.check()
+		})
+	}
+}
+
+func TestNewCloudInfoSource(t *testing.T) {
+	tests := []struct {
+		name      string
+		providers func() []Provider
+	}{
+		{
+			name: "duplicate provider",
+			providers: func() []Provider {
+				return []Provider{onPrem(), onPrem()}
+			},
+		},
+		{
+			name: "region without continent",
+			providers: func() []Provider {
+				p := onPrem()
+				p.Regions[0].Continent = ""
+				return []Provider{p}
+			},
+		},
+		{
+			name: "machine type without memory",
+			providers: func() []Provider {
+				p := onPrem()
+				p.MachineTypes[0].Mem = 0
+				return []Provider{p}
+			},
+		},
+		{
+			name: "machine type in unknown region",
+			providers: func() []Provider {
+				p := onPrem()
+				p.MachineTypes[1].Regions = []string{"dc-paris"}
+				return []Provider{p}
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test // scopelint
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewCloudInfoSource(test.providers())
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCloudInfoSource_recommendation(t *testing.T) {
+	s, err := NewCloudInfoSource([]Provider{onPrem()})
+	assert.NoError(t, err)
+
+	logger := logur.NewTestLogger()
+	engine := recommender.NewEngine(logger, s, vms.NewVmSelector(logger), nodepools.NewNodePoolSelector(logger))
+	resp, err := engine.RecommendCluster(context.Background(), "onprem", "compute", "dc-virginia", recommender.SingleClusterRecommendationReq{
+		ClusterRecommendationReq: recommender.ClusterRecommendationReq{
+			SumCpu:      32,
+			SumMem:      64,
+			MinNodes:    1,
+			MaxNodes:    5,
+			OnDemandPct: 100,
+		},
+	}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(resp.NodePools))
+	assert.Equal(t, "bm.medium", resp.NodePools[0].VmType.Type)
+	assert.Equal(t, 2, resp.NodePools[0].SumNodes)
+	assert.InDelta(t, 0.7, resp.Accuracy.RecTotalPrice, 0.0001)
+}
@@ -15,6 +15,7 @@
 package recommender
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"sort"
@@ -31,67 +32,249 @@ type Engine struct {
 	ciSource         CloudInfoSource
 	vmSelector       VmRecommender
 	nodePoolSelector NodePoolRecommender
+	riskSource       InterruptionRiskSource
+	maxPodsSource    MaxPodsSource
+	perfSource       PerformanceFactorSource
+	costSource       CostComponentSource
+	// hourly Windows license price per vCPU, used for instance types without a Windows price from cloud info
+	windowsLicensePrice float64
+}
+
+// EngineOption configures optional components of the engine
+type EngineOption func(e *Engine)
+
+// WithInterruptionRisks sets the source of the spot interruption frequencies
+func WithInterruptionRisks(riskSource InterruptionRiskSource) EngineOption {
+	return func(e *Engine) {
+		e.riskSource = riskSource
+	}
+}
+
+// WithMaxPods sets the source of the maximum number of pods per node
+func WithMaxPods(maxPodsSource MaxPodsSource) EngineOption {
+	return func(e *Engine) {
+		e.maxPodsSource = maxPodsSource
+	}
+}
+
+// WithPerformanceFactors sets the source of the relative vCPU performance of the instance types
+func WithPerformanceFactors(perfSource PerformanceFactorSource) EngineOption {
+	return func(e *Engine) {
+		e.perfSource = perfSource
+	}
+}
+
+// WithWindowsLicensePrice sets the hourly Windows license price per vCPU
+func WithWindowsLicensePrice(pricePerCpu float64) EngineOption {
+	return func(e *Engine) {
+		e.windowsLicensePrice = pricePerCpu
+	}
+}
+
+// WithCostComponents sets the source of the cluster costs besides the instances
+func WithCostComponents(costSource CostComponentSource) EngineOption {
+	return func(e *Engine) {
+		e.costSource = costSource
+	}
 }
 
 // NewEngine creates a new Engine instance
-func NewEngine(log logur.Logger, ciSource CloudInfoSource, vmSelector VmRecommender, nodePoolSelector NodePoolRecommender) *Engine {
-	return &Engine{
+func NewEngine(log logur.Logger, ciSource CloudInfoSource, vmSelector VmRecommender, nodePoolSelector NodePoolRecommender, opts ...EngineOption) *Engine {
+	e := &Engine{
 		log:              log,
 		ciSource:         ciSource,
 		vmSelector:       vmSelector,
 		nodePoolSelector: nodePoolSelector,
 	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
 }
 
 // RecommendCluster performs recommendation based on the provided arguments
-func (e *Engine) RecommendCluster(provider string, service string, region string, req SingleClusterRecommendationReq, layoutDesc []NodePoolDesc) (*ClusterRecommendationResp, error) {
+func (e *Engine) RecommendCluster(ctx context.Context, provider string, service string, region string, req SingleClusterRecommendationReq, layoutDesc []NodePoolDesc) (*ClusterRecommendationResp, error) {
 	e.log.Info(fmt.Sprintf("recommending cluster configuration. request: [%#v]", req))
 
-	allProducts, err := e.ciSource.GetProductDetails(provider, service, region)
+	allProducts, err := e.getProductDetails(ctx, provider, service, region)
 	if err != nil {
 		return nil, err
 	}
 
-	if req.OnDemandPct != 100 {
-		availableSpotPrice := false
-		for _, vm := range allProducts {
-			if vm.AvgPrice != 0.0 {
-				availableSpotPrice = true
-				break
-			}
-		}
-		if !availableSpotPrice {
-			e.log.Warn("onDemand percentage in the request ignored")
-			req.OnDemandPct = 100
+	if req.MaxInterruptionRisk != nil && !interruptionRiskAvailable(allProducts) {
+		return nil, emperror.With(errors.Errorf("no interruption frequency data available in region %s of provider %s, "+
+			"maxInterruptionRisk can't be applied", region, provider), RecommenderErrorTag)
+	}
+
+	if req.NormalizeCpu && !performanceFactorsAvailable(allProducts) {
+		return nil, emperror.With(errors.Errorf("no performance factors available for the instance types of provider %s, "+
+			"normalizeCpu can't be applied", provider), RecommenderErrorTag)
+	}
+
+	var images nodeImages
+	if req.KubernetesVersion != "" {
+		if images, err = e.kubernetesImages(ctx, provider, service, region, req.Zone, req.KubernetesVersion); err != nil {
+			return nil, err
 		}
+		allProducts = imageProducts(allProducts, images)
+	}
+
+	if req.OnDemandPct != 100 && !spotPriceAvailable(allProducts) {
+		e.log.Warn("onDemand percentage in the request ignored")
+		req.OnDemandPct = 100
 	}
 
-	cheapestMaster, err := e.recommendMaster(provider, service, req, allProducts, layoutDesc)
+	cheapestMaster, err := e.recommendMaster(ctx, provider, service, req, allProducts, layoutDesc)
 	if err != nil {
 		return nil, err
 	}
 
-	cheapestNodePoolSet, err := e.getCheapestNodePoolSet(provider, req, layoutDesc, allProducts)
+	sizingProducts := licensedProducts(allProducts, req.Os)
+	if req.NormalizeCpu {
+		sizingProducts = normalizedProducts(sizingProducts)
+	}
+
+	var zoneCount int
+	if len(req.Resilience) > 0 {
+		zoneCount, err = e.resilienceZoneCount(ctx, provider, service, region, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the node pools are sized for the requested resources extended with the headroom
+	cheapestNodePoolSet, err := e.sizeNodePools(ctx, provider, req, withHeadroom(req), layoutDesc, sizingProducts, zoneCount)
 	if err != nil {
 		return nil, err
 	}
+
+	var headroomPrice float64
+	if req.HeadroomPct > 0 {
+		nodePools, err := e.sizeNodePools(ctx, provider, req, req, layoutDesc, sizingProducts, zoneCount)
+		if err != nil {
+			return nil, err
+		}
+		headroomPrice = math.Max(workerPrice(cheapestNodePoolSet)-workerPrice(nodePools), 0)
+	}
+
+	var resilience []ResilienceCapacity
+	if len(req.Resilience) > 0 {
+		resilience = postFailureCapacities(req.Resilience, cheapestNodePoolSet, zoneCount)
+	}
+
+	if req.SumPods > 0 {
+		cheapestNodePoolSet, err = e.ensurePodCapacity(cheapestNodePoolSet, req.SumPods, req.MaxPodsPerNode, req.MaxNodes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	pods := podCapacity(cheapestNodePoolSet, req.MaxPodsPerNode)
+
+	var surgePrice float64
+	if req.SurgeNodes > 0 {
+		cheapestNodePoolSet, surgePrice = addSurgeNodes(cheapestNodePoolSet, req.SurgeNodes)
+	}
+
+	cheapestNodePoolSet = withOs(withRawVmTypes(cheapestNodePoolSet, allProducts), req.Os)
+
 	if cheapestMaster != nil {
 		cheapestNodePoolSet = append(cheapestNodePoolSet, *cheapestMaster)
 	}
+	cheapestNodePoolSet = withImages(cheapestNodePoolSet, images)
 
 	accuracy := findResponseSum(req.Zone, cheapestNodePoolSet)
+	accuracy.RecResilience = resilience
+	accuracy.RecHeadroomPrice = headroomPrice
+	accuracy.RecSurgePrice = surgePrice
+	accuracy.RecPods = pods
+	accuracy.RecLicensePrice = licensePrice(cheapestNodePoolSet)
+	if req.NormalizeCpu {
+		accuracy.RecComputeUnits = computeUnits(cheapestNodePoolSet)
+	}
+	accuracy, err = e.withCostComponents(ctx, provider, service, region, req.Zone, cheapestNodePoolSet, accuracy)
+	if err != nil {
+		return nil, err
+	}
 
 	return &ClusterRecommendationResp{
-		Provider:  provider,
-		Service:   service,
-		Region:    region,
-		Zone:      req.Zone,
-		NodePools: cheapestNodePoolSet,
-		Accuracy:  accuracy,
+		Provider:          provider,
+		Service:           service,
+		Region:            region,
+		Zone:              req.Zone,
+		NodePools:         cheapestNodePoolSet,
+		Accuracy:          accuracy,
+		Stale:             e.staleProducts(provider, service, region),
+		KubernetesVersion: req.KubernetesVersion,
 	}, nil
 }
 
-func (e *Engine) recommendMaster(provider, service string, req SingleClusterRecommendationReq, allProducts []VirtualMachine, layoutDesc []NodePoolDesc) (*NodePool, error) {
+// getProductDetails retrieves the product details and decorates them with the engine's additional instance type data
+func (e *Engine) getProductDetails(ctx context.Context, provider string, service string, region string) ([]VirtualMachine, error) {
+	allProducts, err := e.ciSource.GetProductDetails(ctx, provider, service, region)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.riskSource != nil {
+		for i := range allProducts {
+			if risk, ok := e.riskSource.InterruptionRisk(provider, region, allProducts[i].Type); ok {
+				allProducts[i].InterruptionRisk = &risk
+			}
+		}
+	}
+
+	if e.maxPodsSource != nil {
+		for i := range allProducts {
+			if maxPods, ok := e.maxPodsSource.MaxPods(provider, allProducts[i].Type); ok {
+				allProducts[i].MaxPods = maxPods
+			}
+		}
+	}
+
+	if e.perfSource != nil {
+		for i := range allProducts {
+			if factor, ok := e.perfSource.PerformanceFactor(provider, allProducts[i].Type); ok {
+				allProducts[i].PerfFactor = factor
+			}
+		}
+	}
+
+	for i := range allProducts {
+		if allProducts[i].WindowsLicensePrice == 0 {
+			allProducts[i].WindowsLicensePrice = allProducts[i].Cpus * e.windowsLicensePrice
+		}
+	}
+
+	return allProducts, nil
+}
+
+// staleProducts checks whether the product details served by the cloud info source are outdated
+func (e *Engine) staleProducts(provider string, service string, region string) bool {
+	source, ok := e.ciSource.(StaleDataSource)
+	return ok && source.StaleProductDetails(provider, service, region)
+}
+
+// interruptionRiskAvailable checks whether the interruption frequency of any of the products is known
+func interruptionRiskAvailable(allProducts []VirtualMachine) bool {
+	for _, vm := range allProducts {
+		if vm.InterruptionRisk != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// spotPriceAvailable checks whether any of the products has a spot price
+func spotPriceAvailable(allProducts []VirtualMachine) bool {
+	for _, vm := range allProducts {
+		if vm.AvgPrice != 0.0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Engine) recommendMaster(ctx context.Context, provider, service string, req SingleClusterRecommendationReq, allProducts []VirtualMachine, layoutDesc []NodePoolDesc) (*NodePool, error) {
 	if layoutDesc != nil {
 		e.log.Debug("there is an existing layout, does not require a master recommendation")
 		return nil, nil
@@ -124,7 +307,7 @@ func (e *Engine) recommendMaster(provider, service string, req SingleClusterReco
 			}
 		}
 
-		masterNodePool, err := e.masterNodeRecommendation(provider, req, allProducts)
+		masterNodePool, err := e.masterNodeRecommendation(ctx, provider, req, allProducts)
 		if err != nil {
 			return nil, err
 		}
@@ -132,7 +315,7 @@ func (e *Engine) recommendMaster(provider, service string, req SingleClusterReco
 		return masterNodePool, nil
 
 	case "ack":
-		masterNodePool, err := e.masterNodeRecommendation(provider, req, allProducts)
+		masterNodePool, err := e.masterNodeRecommendation(ctx, provider, req, allProducts)
 		if err != nil {
 			return nil, err
 		}
@@ -176,7 +359,7 @@ func (e *Engine) recommendMaster(provider, service string, req SingleClusterReco
 	}
 }
 
-func (e *Engine) masterNodeRecommendation(provider string, req SingleClusterRecommendationReq, allProducts []VirtualMachine) (*NodePool, error) {
+func (e *Engine) masterNodeRecommendation(ctx context.Context, provider string, req SingleClusterRecommendationReq, allProducts []VirtualMachine) (*NodePool, error) {
 	request := SingleClusterRecommendationReq{
 		ClusterRecommendationReq: ClusterRecommendationReq{
 			SumCpu:      2,
@@ -189,7 +372,7 @@ func (e *Engine) masterNodeRecommendation(provider string, req SingleClusterReco
 		Includes: req.Includes,
 	}
 
-	cheapestMaster, err := e.getCheapestNodePoolSet(provider, request, nil, allProducts)
+	cheapestMaster, err := e.getCheapestNodePoolSet(ctx, provider, request, nil, allProducts)
 	if err != nil {
 		return nil, err
 	}
@@ -204,7 +387,11 @@ func (e *Engine) masterNodeRecommendation(provider string, req SingleClusterReco
 	return master, nil
 }
 
-func (e *Engine) getCheapestNodePoolSet(provider string, req SingleClusterRecommendationReq, layoutDesc []NodePoolDesc, allProducts []VirtualMachine) ([]NodePool, error) {
+func (e *Engine) getCheapestNodePoolSet(ctx context.Context, provider string, req SingleClusterRecommendationReq, layoutDesc []NodePoolDesc, allProducts []VirtualMachine) ([]NodePool, error) {
+	if onDemandRatioSearched(req) {
+		return e.getOnDemandRatioNodePoolSet(ctx, provider, req, layoutDesc, allProducts)
+	}
+
 	desiredCpu := req.SumCpu
 	desiredMem := req.SumMem
 	desiredOdPct := req.OnDemandPct
@@ -213,7 +400,7 @@ func (e *Engine) getCheapestNodePoolSet(provider string, req SingleClusterRecomm
 	nodePools := make(map[string][]NodePool, 2)
 
 	for _, attr := range attributes {
-		vmsInRange, err := e.vmSelector.FindVmsWithAttrValues(attr, req, layoutDesc, allProducts)
+		vmsInRange, err := e.vmSelector.FindVmsWithAttrValues(ctx, attr, req, layoutDesc, allProducts)
 		if err != nil {
 			return nil, emperror.With(err, RecommenderErrorTag, "vms")
 		}
@@ -233,7 +420,7 @@ func (e *Engine) getCheapestNodePoolSet(provider string, req SingleClusterRecomm
 			}
 		}
 
-		odVms, spotVms, err := e.vmSelector.RecommendVms(provider, vmsInRange, attr, req, layout)
+		odVms, spotVms, err := e.vmSelector.RecommendVms(ctx, provider, vmsInRange, attr, req, layout)
 		if err != nil {
 			return nil, emperror.WrapWith(err, "failed to recommend virtual machines", RecommenderErrorTag)
 		}
@@ -248,7 +435,10 @@ func (e *Engine) getCheapestNodePoolSet(provider string, req SingleClusterRecomm
 			"odVmsCount": len(odVms), "odVmsValues": odVms, "spotVmsCount": len(spotVms), "spotVmsValues": spotVms,
 		})
 
-		nps := e.nodePoolSelector.RecommendNodePools(attr, req, layout, odVms, spotVms)
+		nps, err := e.nodePoolSelector.RecommendNodePools(ctx, attr, req, layout, odVms, spotVms)
+		if err != nil {
+			return nil, emperror.Wrap(err, "failed to recommend node pools")
+		}
 
 		e.log.Debug(fmt.Sprintf("recommended node pools for [%s]: count:[%d] , values: [%#v]", attr, len(nps), nps))
 
@@ -264,7 +454,7 @@ func (e *Engine) getCheapestNodePoolSet(provider string, req SingleClusterRecomm
 }
 
 // RecommendClusterScaleOut performs recommendation for an existing layout's scale out
-func (e *Engine) RecommendClusterScaleOut(provider string, service string, region string, req ClusterScaleoutRecommendationReq) (*ClusterRecommendationResp, error) {
+func (e *Engine) RecommendClusterScaleOut(ctx context.Context, provider string, service string, region string, req ClusterScaleoutRecommendationReq) (*ClusterRecommendationResp, error) {
 	e.log.Info(fmt.Sprintf("recommending cluster configuration. request: [%#v]", req))
 
 	includes := make([]string, len(req.ActualLayout))
@@ -274,39 +464,40 @@ func (e *Engine) RecommendClusterScaleOut(provider string, service string, regio
 
 	clReq := SingleClusterRecommendationReq{
 		ClusterRecommendationReq: ClusterRecommendationReq{
-			AllowBurst:    boolPointer(true),
-			AllowOlderGen: boolPointer(true),
-			MaxNodes:      math.MaxInt8,
-			MinNodes:      1,
-			NetworkPerf:   nil,
-			OnDemandPct:   req.OnDemandPct,
-			SameSize:      false,
-			SumCpu:        req.DesiredCpu,
-			SumMem:        req.DesiredMem,
-			SumGpu:        req.DesiredGpu,
+			AllowBurst:      boolPointer(true),
+			AllowOlderGen:   boolPointer(true),
+			MaxNodes:        math.MaxInt8,
+			MinNodes:        1,
+			NetworkPerf:     nil,
+			OnDemandPct:     req.OnDemandPct,
+			OnDemandPctMode: req.OnDemandPctMode,
+			SameSize:        false,
+			SumCpu:          req.DesiredCpu,
+			SumMem:          req.DesiredMem,
+			SumGpu:          req.DesiredGpu,
 		},
 		Includes: includes,
 		Excludes: req.Excludes,
 		Zone:     req.Zone,
 	}
 
-	return e.RecommendCluster(provider, service, region, clReq, req.ActualLayout)
+	return e.RecommendCluster(ctx, provider, service, region, clReq, req.ActualLayout)
 }
 
 // RecommendMultiCluster performs recommendation
-func (e *Engine) RecommendMultiCluster(req MultiClusterRecommendationReq) (map[string][]*ClusterRecommendationResp, error) {
+func (e *Engine) RecommendMultiCluster(ctx context.Context, req MultiClusterRecommendationReq) (map[string][]*ClusterRecommendationResp, error) {
 	respPerService := make(map[string][]*ClusterRecommendationResp)
 
 	for _, provider := range req.Providers {
 		for _, service := range provider.Services {
-			regions, err := e.getRegions(provider.Provider, service, req.Continents)
+			regions, err := e.getRegions(ctx, provider.Provider, service, req.Continents)
 			if err != nil {
 				return nil, emperror.With(err, RecommenderErrorTag)
 			}
 
 			var responses []*ClusterRecommendationResp
 			for _, region := range regions {
-				if response, err := e.recommendCluster(provider.Provider, service, region, req); err != nil {
+				if response, err := e.recommendCluster(ctx, provider.Provider, service, region, req); err != nil {
 					return nil, emperror.With(err, RecommenderErrorTag)
 				} else if response != nil {
 					responses = append(responses, response)
@@ -328,14 +519,192 @@ func (e *Engine) RecommendMultiCluster(req MultiClusterRecommendationReq) (map[s
 	return respPerService, nil
 }
 
-func (e *Engine) recommendCluster(provider, service, region string, req MultiClusterRecommendationReq) (*ClusterRecommendationResp, error) {
+// QuoteCluster calculates the price of the provided cluster layout
+func (e *Engine) QuoteCluster(ctx context.Context, provider string, service string, region string, req ClusterQuoteReq) (*ClusterRecommendationResp, error) {
+	e.log.Info(fmt.Sprintf("quoting cluster layout. request: [%#v]", req))
+
+	allProducts, err := e.getProductDetails(ctx, provider, service, region)
+	if err != nil {
+		return nil, err
+	}
+
+	nodePools, err := e.describedNodePools(req.Layout, allProducts)
+	if err != nil {
+		return nil, err
+	}
+	nodePools = withOs(nodePools, req.Os)
+
+	master, err := e.recommendMaster(ctx, provider, service, SingleClusterRecommendationReq{Zone: req.Zone}, allProducts, nil)
+	if err != nil {
+		return nil, err
+	}
+	if master != nil {
+		nodePools = append(nodePools, *master)
+	}
+
+	accuracy := findResponseSum(req.Zone, nodePools)
+	accuracy.RecLicensePrice = licensePrice(nodePools)
+	accuracy, err = e.withCostComponents(ctx, provider, service, region, req.Zone, nodePools, accuracy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClusterRecommendationResp{
+		Provider:  provider,
+		Service:   service,
+		Region:    region,
+		Zone:      req.Zone,
+		NodePools: nodePools,
+		Accuracy:  accuracy,
+		Stale:     e.staleProducts(provider, service, region),
+	}, nil
+}
+
+// RecommendClusterSavings compares the costs of the actual layout with the layout recommended for the same resources
+func (e *Engine) RecommendClusterSavings(ctx context.Context, provider string, service string, region string, req ClusterSavingsReq) (*ClusterSavingsResp, error) {
+	e.log.Info(fmt.Sprintf("recommending cluster savings. request: [%#v]", req))
+
+	current, err := e.QuoteCluster(ctx, provider, service, region, ClusterQuoteReq{Zone: req.Zone, Os: req.Os, Layout: req.ActualLayout})
+	if err != nil {
+		return nil, err
+	}
+
+	recReq, err := sameResourcesReq(req.SingleClusterRecommendationReq, current.NodePools)
+	if err != nil {
+		return nil, err
+	}
+
+	recommended, err := e.RecommendCluster(ctx, provider, service, region, recReq, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	hourlySavings := current.Accuracy.RecTotalPrice - recommended.Accuracy.RecTotalPrice
+	var savingsPct float64
+	if current.Accuracy.RecTotalPrice > 0 {
+		savingsPct = hourlySavings / current.Accuracy.RecTotalPrice * 100
+	}
+
+	return &ClusterSavingsResp{
+		Current:                 *current,
+		Recommended:             *recommended,
+		HourlySavings:           hourlySavings,
+		MonthlySavings:          hourlySavings * HoursPerMonth,
+		CurrentMonthlyPrice:     current.Accuracy.RecTotalPrice * HoursPerMonth,
+		RecommendedMonthlyPrice: recommended.Accuracy.RecTotalPrice * HoursPerMonth,
+		SavingsPct:              savingsPct,
+		NodePoolDiff:            diffNodePools(current.NodePools, recommended.NodePools),
+	}, nil
+}
+
+// resourceTolerance is the difference below which two amounts of resources are considered the same
+const resourceTolerance = 0.001
+
+// sameResourcesReq returns the request sized for the worker resources of the layout; the requested resources default
+// to the ones of the layout, the request fails if they differ as the savings of differently sized layouts are misleading
+func sameResourcesReq(req SingleClusterRecommendationReq, layout []NodePool) (SingleClusterRecommendationReq, error) {
+	cpu, mem := workerResources(layout)
+	if (req.SumCpu != 0 && math.Abs(req.SumCpu-cpu) > resourceTolerance) ||
+		(req.SumMem != 0 && math.Abs(req.SumMem-mem) > resourceTolerance) {
+		return req, emperror.With(errors.Errorf("the requested resources (cpu: %v, memory: %v) differ from the resources "+
+			"of the actual layout (cpu: %v, memory: %v)", req.SumCpu, req.SumMem, cpu, mem), RecommenderErrorTag)
+	}
+	req.SumCpu = cpu
+	req.SumMem = mem
+	return req, nil
+}
+
+// workerResources returns the cpus and memory of the worker node pools
+func workerResources(nodePools []NodePool) (float64, float64) {
+	var cpu, mem float64
+	for _, np := range nodePools {
+		if np.Role != Master {
+			cpu += np.GetSum(Cpu)
+			mem += np.GetSum(Memory)
+		}
+	}
+	return cpu, mem
+}
+
+// diffNodePools collects the node count changes between two layouts, node pools empty in both layouts are left out
+func diffNodePools(current, recommended []NodePool) []NodePoolDiff {
+	type poolKey struct {
+		instanceType, vmClass, role string
+	}
+	diffs := make([]NodePoolDiff, 0)
+	idx := make(map[poolKey]int)
+
+	add := func(np NodePool, current bool) {
+		key := poolKey{np.VmType.Type, np.VmClass, np.Role}
+		i, ok := idx[key]
+		if !ok {
+			i = len(diffs)
+			idx[key] = i
+			diffs = append(diffs, NodePoolDiff{InstanceType: np.VmType.Type, VmClass: np.VmClass, Role: np.Role})
+		}
+		if current {
+			diffs[i].CurrentNodes += np.SumNodes
+			diffs[i].PriceDiff -= np.PoolPrice()
+		} else {
+			diffs[i].RecommendedNodes += np.SumNodes
+			diffs[i].PriceDiff += np.PoolPrice()
+		}
+		diffs[i].Diff = diffs[i].RecommendedNodes - diffs[i].CurrentNodes
+	}
+
+	for _, np := range current {
+		add(np, true)
+	}
+	for _, np := range recommended {
+		add(np, false)
+	}
+
+	nonEmpty := diffs[:0]
+	for _, d := range diffs {
+		if d.CurrentNodes != 0 || d.RecommendedNodes != 0 {
+			nonEmpty = append(nonEmpty, d)
+		}
+	}
+	return nonEmpty
+}
+
+// describedNodePools looks up the products for the node pool descriptions, fails if any of the instance types is unknown
+func (e *Engine) describedNodePools(layoutDesc []NodePoolDesc, allProducts []VirtualMachine) ([]NodePool, error) {
+	nps := make([]NodePool, 0, len(layoutDesc))
+	for _, npd := range layoutDesc {
+		var vm *VirtualMachine
+		for i := range allProducts {
+			if allProducts[i].Type == npd.InstanceType {
+				vm = &allProducts[i]
+				break
+			}
+		}
+		if vm == nil {
+			return nil, emperror.With(errors.Errorf("unknown instance type: %s", npd.InstanceType), RecommenderErrorTag)
+		}
+
+		np := NodePool{
+			VmType:   *vm,
+			VmClass:  npd.GetVmClass(),
+			SumNodes: npd.SumNodes,
+			Role:     Worker,
+		}
+		if np.VmClass == Spot && vm.AvgPrice == 0 {
+			return nil, emperror.With(errors.Errorf("no spot price available for instance type: %s", npd.InstanceType), RecommenderErrorTag)
+		}
+		nps = append(nps, np)
+	}
+	return nps, nil
+}
+
+func (e *Engine) recommendCluster(ctx context.Context, provider, service, region string, req MultiClusterRecommendationReq) (*ClusterRecommendationResp, error) {
 	var (
 		response *ClusterRecommendationResp
 		err      error
 	)
 
 	if service == "ack" {
-		zones, err := e.ciSource.GetZones(provider, service, region)
+		zones, err := e.ciSource.GetZones(ctx, provider, service, region)
 		if err != nil {
 			return nil, err
 		}
@@ -346,8 +715,11 @@ func (e *Engine) recommendCluster(provider, service, region string, req MultiClu
 				Includes:                 req.Includes[provider][service],
 				Zone:                     zone,
 			}
-			zoneResp, err := e.RecommendCluster(provider, service, region, request, nil)
+			zoneResp, err := e.RecommendCluster(ctx, provider, service, region, request, nil)
 			if err != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
 				e.log.Warn("could not recommend cluster")
 				continue
 			}
@@ -362,17 +734,21 @@ func (e *Engine) recommendCluster(provider, service, region string, req MultiClu
 			Includes:                 req.Includes[provider][service],
 		}
 
-		response, err = e.RecommendCluster(provider, service, region, request, nil)
+		response, err = e.RecommendCluster(ctx, provider, service, region, request, nil)
 		if err != nil {
+			// the failures of single regions are skipped unless the whole request is cancelled or timed out
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
 			e.log.Warn("could not recommend cluster")
 		}
 	}
 	return response, nil
 }
 
-func (e *Engine) getRegions(provider, service string, continents []string) ([]string, error) {
+func (e *Engine) getRegions(ctx context.Context, provider, service string, continents []string) ([]string, error) {
 	var regions []string
-	continentsData, err := e.ciSource.GetContinentsData(provider, service)
+	continentsData, err := e.ciSource.GetContinentsData(ctx, provider, service)
 	if err != nil {
 		return nil, err
 	}
@@ -433,6 +809,8 @@ func findResponseSum(zone string, nodePoolSet []NodePool) ClusterRecommendationA
 	var sumWorkerPrice float64
 	var sumMasterPrice float64
 	var sumTotalPrice float64
+	var sumSpotRisk float64
+	var sumSpotRiskNodes int
 	for _, nodePool := range nodePoolSet {
 		sumCpus += nodePool.GetSum(Cpu)
 		sumMem += nodePool.GetSum(Memory)
@@ -447,6 +825,10 @@ func findResponseSum(zone string, nodePoolSet []NodePool) ClusterRecommendationA
 			} else {
 				sumSpotPrice += nodePool.PoolPrice()
 				sumSpotNodes += nodePool.SumNodes
+				if nodePool.VmType.InterruptionRisk != nil {
+					sumSpotRisk += *nodePool.VmType.InterruptionRisk * float64(nodePool.SumNodes)
+					sumSpotRiskNodes += nodePool.SumNodes
+				}
 			}
 		case Master:
 			sumMasterPrice += nodePool.PoolPrice()
@@ -455,19 +837,84 @@ func findResponseSum(zone string, nodePoolSet []NodePool) ClusterRecommendationA
 		sumTotalPrice += nodePool.PoolPrice()
 	}
 
+	var spotRisk float64
+	if sumSpotRiskNodes > 0 {
+		spotRisk = sumSpotRisk / float64(sumSpotRiskNodes)
+	}
+
 	return ClusterRecommendationAccuracy{
-		RecCpu:          sumCpus,
-		RecMem:          sumMem,
-		RecNodes:        sumWorkerNodes,
-		RecZone:         zone,
-		RecRegularPrice: sumRegularPrice,
-		RecRegularNodes: sumRegularNodes,
-		RecSpotPrice:    sumSpotPrice,
-		RecSpotNodes:    sumSpotNodes,
-		RecWorkerPrice:  sumWorkerPrice,
-		RecMasterPrice:  sumMasterPrice,
-		RecTotalPrice:   sumTotalPrice,
+		RecCpu:                  sumCpus,
+		RecMem:                  sumMem,
+		RecCpuQuantity:          CpuQuantity(sumCpus),
+		RecMemQuantity:          MemQuantity(sumMem),
+		RecNodes:                sumWorkerNodes,
+		RecZone:                 zone,
+		RecRegularPrice:         sumRegularPrice,
+		RecRegularNodes:         sumRegularNodes,
+		RecSpotPrice:            sumSpotPrice,
+		RecSpotNodes:            sumSpotNodes,
+		RecWorkerPrice:          sumWorkerPrice,
+		RecMasterPrice:          sumMasterPrice,
+		RecTotalPrice:           sumTotalPrice,
+		RecSpotInterruptionRisk: spotRisk,
+	}
+}
+
+// withHeadroom returns the request with its resources extended by the requested headroom percentage
+func withHeadroom(req SingleClusterRecommendationReq) SingleClusterRecommendationReq {
+	if req.HeadroomPct > 0 {
+		req.SumCpu = req.SumCpu * float64(100+req.HeadroomPct) / 100
+		req.SumMem = req.SumMem * float64(100+req.HeadroomPct) / 100
+	}
+	return req
+}
+
+// sizeNodePools recommends the cheapest node pools for the sizing request, the node pools are enlarged until they
+// survive the requested failures with the resources of the original request
+func (e *Engine) sizeNodePools(ctx context.Context, provider string, req, sizingReq SingleClusterRecommendationReq, layoutDesc []NodePoolDesc,
+	allProducts []VirtualMachine, zoneCount int,
+) ([]NodePool, error) {
+	if len(req.Resilience) > 0 {
+		return e.getResilientNodePoolSet(ctx, provider, req, sizingReq, layoutDesc, allProducts, zoneCount)
+	}
+	return e.getCheapestNodePoolSet(ctx, provider, sizingReq, layoutDesc, allProducts)
+}
+
+// workerPrice returns the price of the worker node pools
+func workerPrice(nodePools []NodePool) float64 {
+	var price float64
+	for _, np := range nodePools {
+		if np.Role != Master {
+			price += np.PoolPrice()
+		}
+	}
+	return price
+}
+
+// addSurgeNodes adds the surge nodes to the worker pool with the largest instance type,
+// so that any of the node pools can be upgraded with surging; returns the price of the surge nodes
+func addSurgeNodes(nodePools []NodePool, surgeNodes int) ([]NodePool, float64) {
+	largest := -1
+	for i, np := range nodePools {
+		if np.Role == Master || np.SumNodes == 0 {
+			continue
+		}
+		if largest < 0 || np.VmType.Cpus > nodePools[largest].VmType.Cpus ||
+			(np.VmType.Cpus == nodePools[largest].VmType.Cpus && np.VmType.Mem > nodePools[largest].VmType.Mem) {
+			largest = i
+		}
 	}
+	if largest < 0 {
+		return nodePools, 0
+	}
+
+	nps := make([]NodePool, len(nodePools))
+	copy(nps, nodePools)
+	nps[largest].SumNodes += surgeNodes
+
+	surge := nps[largest]
+	surge.SumNodes = surgeNodes
+	return nps, surge.PoolPrice()
 }
 
 // findCheapestNodePoolSet looks up the "cheapest" node pool set from the provided map
@@ -15,9 +15,12 @@
 package recommender
 
 import (
+	"context"
+	"math"
 	"testing"
 
 	"github.com/banzaicloud/telescopes/.gen/cloudinfo"
+	"github.com/goph/emperror"
 	"github.com/goph/logur"
 	"github.com/stretchr/testify/assert"
 )
@@ -27,33 +30,34 @@ type dummyProducts struct {
 	TcId string
 }
 
-func (p *dummyProducts) GetContinents() ([]string, error) {
+func (p *dummyProducts) GetContinents(ctx context.Context) ([]string, error) {
 	panic("implement me")
 }
 
-func (p *dummyProducts) GetRegion(provider string, service string, region string) (string, error) {
+func (p *dummyProducts) GetRegion(ctx context.Context, provider string, service string, region string) (string, error) {
 	panic("implement me")
 }
 
-func (p *dummyProducts) GetProvider(provider string) (string, error) {
+func (p *dummyProducts) GetProvider(ctx context.Context, provider string) (string, error) {
 	panic("implement me")
 }
 
-func (p *dummyProducts) GetService(provider string, service string) (string, error) {
+func (p *dummyProducts) GetService(ctx context.Context, provider string, service string) (string, error) {
 	panic("implement me")
 }
 
-func (p *dummyProducts) GetContinentsData(provider, service string) ([]cloudinfo.Continent, error) {
+func (p *dummyProducts) GetContinentsData(ctx context.Context, provider, service string) ([]cloudinfo.Continent, error) {
 	panic("implement me")
 }
 
-func (p *dummyProducts) GetZones(prv, svc, reg string) ([]string, error) {
-	panic("implement me")
+func (p *dummyProducts) GetZones(ctx context.Context, prv, svc, reg string) ([]string, error) {
+	return []string{"dummyZone1", "dummyZone2", "dummyZone3"}, nil
 }
 
-func (p *dummyProducts) GetProductDetails(provider string, service string, region string) ([]VirtualMachine, error) {
+func (p *dummyProducts) GetProductDetails(ctx context.Context, provider string, service string, region string) ([]VirtualMachine, error) {
 	return []VirtualMachine{
 		{
+			Type:          "dummy-type",
 			Cpus:          16,
 			Mem:           42,
 			OnDemandPrice: 3,
@@ -62,16 +66,48 @@ func (p *dummyProducts) GetProductDetails(provider string, service string, regio
 	}, nil
 }
 
-func (p *dummyProducts) GetRegions(provider, service string) ([]cloudinfo.Region, error) {
+func (p *dummyProducts) GetRegions(ctx context.Context, provider, service string) ([]cloudinfo.Region, error) {
 	return nil, nil
 }
 
+func (p *dummyProducts) GetImages(ctx context.Context, provider string, service string, region string) ([]cloudinfo.Image, error) {
+	return []cloudinfo.Image{
+		{Name: "dummy-image-1.13", Version: "1.13.5"},
+		{Name: "dummy-image-1.14", Version: "1.14.1"},
+		{Name: "dummy-gpu-image-1.14", Version: "1.14.1", Gpu: true},
+	}, nil
+}
+
+func (p *dummyProducts) GetVersions(ctx context.Context, provider string, service string, region string) ([]cloudinfo.LocationVersion, error) {
+	return []cloudinfo.LocationVersion{
+		{Location: region, Default: "1.14.1", Versions: []string{"1.13.5", "1.14.1"}},
+	}, nil
+}
+
+type dummyRisks map[string]float64
+
+func (r dummyRisks) InterruptionRisk(provider, region, instanceType string) (float64, bool) {
+	risk, ok := r[instanceType]
+	return risk, ok
+}
+
+type dummyPerformanceFactors map[string]float64
+
+func (f dummyPerformanceFactors) PerformanceFactor(provider, instanceType string) (float64, bool) {
+	factor, ok := f[instanceType]
+	return factor, ok
+}
+
+func float64Pointer(f float64) *float64 {
+	return &f
+}
+
 type dummyVms struct {
 	// test case id to drive the behaviour
 	TcId string
 }
 
-func (v *dummyVms) RecommendVms(provider string, vms []VirtualMachine, attr string, req SingleClusterRecommendationReq, layout []NodePool) ([]VirtualMachine, []VirtualMachine, error) {
+func (v *dummyVms) RecommendVms(ctx context.Context, provider string, vms []VirtualMachine, attr string, req SingleClusterRecommendationReq, layout []NodePool) ([]VirtualMachine, []VirtualMachine, error) {
 	return nil, []VirtualMachine{
 		{
 			Cpus:          16,
@@ -100,7 +136,7 @@ func (v *dummyVms) RecommendVms(provider string, vms []VirtualMachine, attr stri
 	}, nil
 }
 
-func (v *dummyVms) FindVmsWithAttrValues(attr string, req SingleClusterRecommendationReq, layoutDesc []NodePoolDesc, allProducts []VirtualMachine) ([]VirtualMachine, error) {
+func (v *dummyVms) FindVmsWithAttrValues(ctx context.Context, attr string, req SingleClusterRecommendationReq, layoutDesc []NodePoolDesc, allProducts []VirtualMachine) ([]VirtualMachine, error) {
 	return nil, nil
 }
 
@@ -109,7 +145,7 @@ type dummyNodePools struct {
 	TcId string
 }
 
-func (nps *dummyNodePools) RecommendNodePools(attr string, req SingleClusterRecommendationReq, layout []NodePool, odVms []VirtualMachine, spotVms []VirtualMachine) []NodePool {
+func (nps *dummyNodePools) RecommendNodePools(ctx context.Context, attr string, req SingleClusterRecommendationReq, layout []NodePool, odVms []VirtualMachine, spotVms []VirtualMachine) ([]NodePool, error) {
 	return []NodePool{
 		{ // price = 2*3 +2*2 = 10
 			VmType: VirtualMachine{
@@ -161,7 +197,25 @@ func (nps *dummyNodePools) RecommendNodePools(attr string, req SingleClusterReco
 			SumNodes: 0,
 			VmClass:  Spot,
 		},
-	}
+	}, nil
+}
+
+// sizedNodePools recommends a single regular node pool sized for the requested cpus
+type sizedNodePools struct{}
+
+func (nps *sizedNodePools) RecommendNodePools(ctx context.Context, attr string, req SingleClusterRecommendationReq, layout []NodePool, odVms []VirtualMachine, spotVms []VirtualMachine) ([]NodePool, error) {
+	return []NodePool{
+		{
+			VmType: VirtualMachine{
+				Cpus:          16,
+				Mem:           42,
+				AvgPrice:      2,
+				OnDemandPrice: 3,
+			},
+			SumNodes: int(math.Ceil(req.SumCpu / 16)),
+			VmClass:  Regular,
+		},
+	}, nil
 }
 
 func TestEngine_RecommendCluster(t *testing.T) {
@@ -170,6 +224,7 @@ func TestEngine_RecommendCluster(t *testing.T) {
 		vms      VmRecommender
 		np       NodePoolRecommender
 		ciSource CloudInfoSource
+		opts     []EngineOption
 		request  SingleClusterRecommendationReq
 		check    func(resp *ClusterRecommendationResp, err error)
 	}{
@@ -192,13 +247,159 @@ func TestEngine_RecommendCluster(t *testing.T) {
 				assert.Equal(t, float64(16), resp.Accuracy.RecCpu)
 			},
 		},
+		{
+			name: "cluster recommendation with headroom and surge nodes",
+			vms:  &dummyVms{},
+			np:   &sizedNodePools{},
+			request: SingleClusterRecommendationReq{
+				ClusterRecommendationReq: ClusterRecommendationReq{
+					MinNodes:    1,
+					MaxNodes:    1,
+					SumMem:      32,
+					SumCpu:      16,
+					HeadroomPct: 100,
+					SurgeNodes:  2,
+				},
+			},
+			ciSource: &dummyProducts{},
+			check: func(resp *ClusterRecommendationResp, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, float64(3), resp.Accuracy.RecHeadroomPrice)
+				assert.Equal(t, float64(6), resp.Accuracy.RecSurgePrice)
+				assert.Equal(t, float64(12), resp.Accuracy.RecTotalPrice)
+			},
+		},
+		{
+			name: "cluster recommendation with kubernetes version",
+			vms:  &dummyVms{},
+			np:   &dummyNodePools{},
+			request: SingleClusterRecommendationReq{
+				ClusterRecommendationReq: ClusterRecommendationReq{
+					MinNodes:          1,
+					MaxNodes:          1,
+					SumMem:            32,
+					SumCpu:            16,
+					KubernetesVersion: "1.14.1",
+				},
+			},
+			ciSource: &dummyProducts{},
+			check: func(resp *ClusterRecommendationResp, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, "1.14.1", resp.KubernetesVersion)
+				assert.NotEmpty(t, resp.NodePools)
+				for _, np := range resp.NodePools {
+					assert.Equal(t, "dummy-image-1.14", np.Image)
+				}
+			},
+		},
+		{
+			name: "cluster recommendation with kubernetes version not offered",
+			vms:  &dummyVms{},
+			np:   &dummyNodePools{},
+			request: SingleClusterRecommendationReq{
+				ClusterRecommendationReq: ClusterRecommendationReq{
+					MinNodes:          1,
+					MaxNodes:          1,
+					SumMem:            32,
+					SumCpu:            16,
+					KubernetesVersion: "1.11.0",
+				},
+			},
+			ciSource: &dummyProducts{},
+			check: func(resp *ClusterRecommendationResp, err error) {
+				assert.Nil(t, resp)
+				assert.Contains(t, emperror.Context(err), RecommenderErrorTag)
+			},
+		},
+		{
+			name: "cluster recommendation with max interruption risk",
+			vms:  &dummyVms{},
+			np:   &dummyNodePools{},
+			request: SingleClusterRecommendationReq{
+				ClusterRecommendationReq: ClusterRecommendationReq{
+					MinNodes:            1,
+					MaxNodes:            1,
+					SumMem:              32,
+					SumCpu:              16,
+					MaxInterruptionRisk: float64Pointer(10),
+				},
+			},
+			ciSource: &dummyProducts{},
+			opts:     []EngineOption{WithInterruptionRisks(dummyRisks{"dummy-type": 5})},
+			check: func(resp *ClusterRecommendationResp, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.NotEmpty(t, resp.NodePools)
+			},
+		},
+		{
+			name: "cluster recommendation with max interruption risk without interruption data",
+			vms:  &dummyVms{},
+			np:   &dummyNodePools{},
+			request: SingleClusterRecommendationReq{
+				ClusterRecommendationReq: ClusterRecommendationReq{
+					MinNodes:            1,
+					MaxNodes:            1,
+					SumMem:              32,
+					SumCpu:              16,
+					MaxInterruptionRisk: float64Pointer(10),
+				},
+			},
+			ciSource: &dummyProducts{},
+			check: func(resp *ClusterRecommendationResp, err error) {
+				assert.Nil(t, resp)
+				assert.EqualError(t, err, "no interruption frequency data available in region dummyRegion of provider dummyProvider, "+
+					"maxInterruptionRisk can't be applied")
+				assert.Contains(t, emperror.Context(err), RecommenderErrorTag)
+			},
+		},
+		{
+			name: "cluster recommendation with normalized cpus",
+			vms:  &dummyVms{},
+			np:   &dummyNodePools{},
+			request: SingleClusterRecommendationReq{
+				ClusterRecommendationReq: ClusterRecommendationReq{
+					MinNodes:     1,
+					MaxNodes:     1,
+					SumMem:       32,
+					SumCpu:       16,
+					NormalizeCpu: true,
+				},
+			},
+			ciSource: &dummyProducts{},
+			opts:     []EngineOption{WithPerformanceFactors(dummyPerformanceFactors{"dummy-type": 1.5})},
+			check: func(resp *ClusterRecommendationResp, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.NotEmpty(t, resp.NodePools)
+			},
+		},
+		{
+			name: "cluster recommendation with normalized cpus without performance factors",
+			vms:  &dummyVms{},
+			np:   &dummyNodePools{},
+			request: SingleClusterRecommendationReq{
+				ClusterRecommendationReq: ClusterRecommendationReq{
+					MinNodes:     1,
+					MaxNodes:     1,
+					SumMem:       32,
+					SumCpu:       16,
+					NormalizeCpu: true,
+				},
+			},
+			ciSource: &dummyProducts{},
+			check: func(resp *ClusterRecommendationResp, err error) {
+				assert.Nil(t, resp)
+				assert.EqualError(t, err, "no performance factors available for the instance types of provider dummyProvider, "+
+					"normalizeCpu can't be applied")
+				assert.Contains(t, emperror.Context(err), RecommenderErrorTag)
+			},
+		},
 	}
 	for _, test := range tests {
 		test := test
 		t.Run(test.name, func(t *testing.T) {
-			engine := NewEngine(logur.NewTestLogger(), test.ciSource, test.vms, test.np)
+			engine := NewEngine(logur.NewTestLogger(), test.ciSource, test.vms, test.np, test.opts...)
 
-			test.check(engine.RecommendCluster("dummyProvider", "dummyService", "dummyRegion", test.request, nil))
+			test.check(engine.RecommendCluster(context.Background(), "dummyProvider", "dummyService", "dummyRegion", test.request, nil))
 		})
 	}
 }
@@ -271,3 +472,181 @@ func TestEngine_findCheapestNodePoolSet(t *testing.T) {
 		})
 	}
 }
+
+func TestEngine_QuoteCluster(t *testing.T) {
+	tests := []struct {
+		name     string
+		ciSource CloudInfoSource
+		opts     []EngineOption
+		request  ClusterQuoteReq
+		check    func(resp *ClusterRecommendationResp, err error)
+	}{
+		{
+			name:     "cluster quote success",
+			ciSource: &dummyProducts{},
+			request: ClusterQuoteReq{
+				Layout: []NodePoolDesc{
+					{InstanceType: "dummy-type", VmClass: Regular, SumNodes: 2},
+					{InstanceType: "dummy-type", VmClass: Spot, SumNodes: 5},
+				},
+			},
+			check: func(resp *ClusterRecommendationResp, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 2, len(resp.NodePools))
+				assert.Equal(t, 7, resp.Accuracy.RecNodes)
+				assert.Equal(t, float64(112), resp.Accuracy.RecCpu)
+				assert.Equal(t, float64(6), resp.Accuracy.RecRegularPrice)
+				assert.Equal(t, float64(4), resp.Accuracy.RecSpotPrice)
+				assert.Equal(t, float64(10), resp.Accuracy.RecTotalPrice)
+			},
+		},
+		{
+			name:     "windows cluster quote",
+			ciSource: &dummyProducts{},
+			opts:     []EngineOption{WithWindowsLicensePrice(0.05)},
+			request: ClusterQuoteReq{
+				Os: OsWindows,
+				Layout: []NodePoolDesc{
+					{InstanceType: "dummy-type", VmClass: Regular, SumNodes: 2},
+				},
+			},
+			check: func(resp *ClusterRecommendationResp, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, OsWindows, resp.NodePools[0].Os)
+				assert.InDelta(t, 2*0.8, resp.Accuracy.RecLicensePrice, 1e-9)
+				assert.InDelta(t, 6+2*0.8, resp.Accuracy.RecTotalPrice, 1e-9)
+			},
+		},
+		{
+			name:     "cluster quote fails for unknown instance type",
+			ciSource: &dummyProducts{},
+			request: ClusterQuoteReq{
+				Layout: []NodePoolDesc{
+					{InstanceType: "unknown-type", VmClass: Regular, SumNodes: 2},
+				},
+			},
+			check: func(resp *ClusterRecommendationResp, err error) {
+				assert.Nil(t, resp, "the response should be nil")
+				assert.EqualError(t, err, "unknown instance type: unknown-type")
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			engine := NewEngine(logur.NewTestLogger(), test.ciSource, &dummyVms{}, &dummyNodePools{}, test.opts...)
+
+			test.check(engine.QuoteCluster(context.Background(), "dummyProvider", "dummyService", "dummyRegion", test.request))
+		})
+	}
+}
+
+func TestEngine_RecommendClusterSavings(t *testing.T) {
+	tests := []struct {
+		name     string
+		ciSource CloudInfoSource
+		opts     []EngineOption
+		request  ClusterSavingsReq
+		check    func(resp *ClusterSavingsResp, err error)
+	}{
+		{
+			name:     "cluster savings success",
+			ciSource: &dummyProducts{},
+			request: ClusterSavingsReq{
+				SingleClusterRecommendationReq: SingleClusterRecommendationReq{
+					ClusterRecommendationReq: ClusterRecommendationReq{
+						MinNodes: 1,
+						MaxNodes: 1,
+					},
+				},
+				ActualLayout: []NodePoolDesc{
+					{InstanceType: "dummy-type", VmClass: Regular, SumNodes: 2},
+				},
+			},
+			check: func(resp *ClusterSavingsResp, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, float64(6), resp.Current.Accuracy.RecTotalPrice)
+				assert.Equal(t, float64(2), resp.Recommended.Accuracy.RecTotalPrice)
+				assert.Equal(t, float64(4), resp.HourlySavings)
+				assert.Equal(t, float64(4*HoursPerMonth), resp.MonthlySavings)
+				assert.InDelta(t, 66.67, resp.SavingsPct, 0.01)
+				assert.Equal(t, 2, len(resp.NodePoolDiff))
+				assert.Equal(t, -2, resp.NodePoolDiff[0].Diff)
+				assert.Equal(t, 1, resp.NodePoolDiff[1].Diff)
+			},
+		},
+		{
+			name:     "windows cluster savings",
+			ciSource: &dummyProducts{},
+			opts:     []EngineOption{WithWindowsLicensePrice(0.05)},
+			request: ClusterSavingsReq{
+				SingleClusterRecommendationReq: SingleClusterRecommendationReq{
+					ClusterRecommendationReq: ClusterRecommendationReq{
+						MinNodes: 1,
+						MaxNodes: 1,
+						Os:       OsWindows,
+					},
+				},
+				ActualLayout: []NodePoolDesc{
+					{InstanceType: "dummy-type", VmClass: Regular, SumNodes: 2},
+				},
+			},
+			check: func(resp *ClusterSavingsResp, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.InDelta(t, 6+2*0.8, resp.Current.Accuracy.RecTotalPrice, 1e-9)
+				assert.InDelta(t, resp.Current.Accuracy.RecTotalPrice-resp.Recommended.Accuracy.RecTotalPrice, resp.HourlySavings, 1e-9)
+			},
+		},
+		{
+			name:     "requested resources matching the actual layout",
+			ciSource: &dummyProducts{},
+			request: ClusterSavingsReq{
+				SingleClusterRecommendationReq: SingleClusterRecommendationReq{
+					ClusterRecommendationReq: ClusterRecommendationReq{
+						MinNodes: 1,
+						MaxNodes: 1,
+						SumCpu:   32,
+						SumMem:   84,
+					},
+				},
+				ActualLayout: []NodePoolDesc{
+					{InstanceType: "dummy-type", VmClass: Regular, SumNodes: 2},
+				},
+			},
+			check: func(resp *ClusterSavingsResp, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, float64(4), resp.HourlySavings)
+			},
+		},
+		{
+			name:     "requested resources differing from the actual layout",
+			ciSource: &dummyProducts{},
+			request: ClusterSavingsReq{
+				SingleClusterRecommendationReq: SingleClusterRecommendationReq{
+					ClusterRecommendationReq: ClusterRecommendationReq{
+						MinNodes: 1,
+						MaxNodes: 1,
+						SumCpu:   16,
+						SumMem:   32,
+					},
+				},
+				ActualLayout: []NodePoolDesc{
+					{InstanceType: "dummy-type", VmClass: Regular, SumNodes: 2},
+				},
+			},
+			check: func(resp *ClusterSavingsResp, err error) {
+				assert.EqualError(t, err, "the requested resources (cpu: 16, memory: 32) differ from the resources "+
+					"of the actual layout (cpu: 32, memory: 84)")
+				assert.Nil(t, resp, "the response should be nil")
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			engine := NewEngine(logur.NewTestLogger(), test.ciSource, &dummyVms{}, &dummyNodePools{}, test.opts...)
+
+			test.check(engine.RecommendClusterSavings(context.Background(), "dummyProvider", "dummyService", "dummyRegion", test.request))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// sizeRegexp matches the size part of an azure series, eg. 4 in D4s or 64-32 in E64-32s
+	sizeRegexp = regexp.MustCompile(`[0-9]+(-[0-9]+)?`)
+	// versionRegexp matches the version of an azure series, eg. v3 in Standard_Ds_v3
+	versionRegexp = regexp.MustCompile(`v[0-9]+$`)
+	// digitsRegexp matches the first number in a family name, eg. 5 in m5a
+	digitsRegexp = regexp.MustCompile(`[0-9]+`)
+)
+
+// InstanceFamily returns the family of the instance type by removing its size,
+// eg. m5.xlarge -> m5, n1-standard-4 -> n1-standard, Standard_D4s_v3 -> Standard_Ds_v3
+func InstanceFamily(instanceType string) string {
+	switch {
+	case strings.Contains(instanceType, "."):
+		// amazon, alibaba and oracle types: <family>.<size>
+		return instanceType[:strings.LastIndex(instanceType, ".")]
+	case strings.Contains(instanceType, "_"):
+		// azure types: <tier>_<series with size>[_<version>]
+		parts := strings.SplitN(instanceType, "_", 3)
+		parts[1] = strings.Replace(parts[1], sizeRegexp.FindString(parts[1]), "", 1)
+		return strings.Join(parts, "_")
+	case strings.Contains(instanceType, "-"):
+		// google types: <series>-<type>[-<size>]
+		idx := strings.LastIndex(instanceType, "-")
+		if digitsRegexp.FindString(instanceType[idx+1:]) == instanceType[idx+1:] {
+			return instanceType[:idx]
+		}
+	}
+	return instanceType
+}
+
+// InstanceGeneration returns the generation of the instance type, empty if it can't be determined,
+// eg. m5.xlarge -> 5, n1-standard-4 -> 1, Standard_D4s_v3 -> v3
+func InstanceGeneration(instanceType string) string {
+	family := InstanceFamily(instanceType)
+	if strings.Contains(family, "_") {
+		if version := versionRegexp.FindString(family); version != "" {
+			return version
+		}
+		return "v1"
+	}
+	return digitsRegexp.FindString(family)
+}
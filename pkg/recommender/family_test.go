@@ -0,0 +1,47 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstanceFamily(t *testing.T) {
+	tests := []struct {
+		instanceType string
+		family       string
+		generation   string
+	}{
+		{instanceType: "m5.xlarge", family: "m5", generation: "5"},
+		{instanceType: "c5n.18xlarge", family: "c5n", generation: "5"},
+		{instanceType: "ecs.g5.large", family: "ecs.g5", generation: "5"},
+		{instanceType: "VM.Standard2.4", family: "VM.Standard2", generation: "2"},
+		{instanceType: "n1-standard-4", family: "n1-standard", generation: "1"},
+		{instanceType: "f1-micro", family: "f1-micro", generation: "1"},
+		{instanceType: "Standard_D4s_v3", family: "Standard_Ds_v3", generation: "v3"},
+		{instanceType: "Standard_E64-32s_v3", family: "Standard_Es_v3", generation: "v3"},
+		{instanceType: "Standard_DS2", family: "Standard_DS", generation: "v1"},
+		{instanceType: "custom", family: "custom", generation: ""},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.instanceType, func(t *testing.T) {
+			assert.Equal(t, test.family, InstanceFamily(test.instanceType))
+			assert.Equal(t, test.generation, InstanceGeneration(test.instanceType))
+		})
+	}
+}
@@ -0,0 +1,336 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package federation
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/banzaicloud/telescopes/.gen/cloudinfo"
+	"github.com/banzaicloud/telescopes/pkg/recommender"
+	"github.com/go-openapi/runtime"
+	"github.com/goph/emperror"
+	"github.com/goph/logur"
+	"github.com/pkg/errors"
+)
+
+// BackendConfig describes a cloud info backend and the providers routed to it
+type BackendConfig struct {
+	// Name of the backend, used in the logs and metrics
+	Name string
+	// Address of the cloud info service
+	Address string
+	// Directory of a cloud info snapshot used instead of the cloud info service if set
+	SnapshotDir string
+	// Providers served by the backend, the backend serves the providers not routed elsewhere if empty
+	Providers []string
+}
+
+// Backend is a cloud info source serving the providers
+type Backend struct {
+	Name      string
+	Providers []string
+	Source    recommender.CloudInfoSource
+}
+
+func (b Backend) serves(provider string) bool {
+	for _, p := range b.Providers {
+		if p == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// CloudInfoSource is a CloudInfoSource routing the calls to the backends by provider; the responses of the providers
+// served by several backends are merged, the data of the backend listed first wins on conflicts
+type CloudInfoSource struct {
+	backends []Backend
+	log      logur.Logger
+}
+
+// NewCloudInfoSource creates a cloud info source federating the backends
+func NewCloudInfoSource(backends []Backend, log logur.Logger) (*CloudInfoSource, error) {
+	if len(backends) == 0 {
+		return nil, errors.New("no cloud info backends configured")
+	}
+	names := make(map[string]bool, len(backends))
+	for _, b := range backends {
+		if names[b.Name] {
+			return nil, errors.Errorf("duplicate cloud info backend %q", b.Name)
+		}
+		names[b.Name] = true
+	}
+
+	return &CloudInfoSource{
+		backends: backends,
+		log:      logur.WithFields(log, map[string]interface{}{"component": "cloud-info-federation"}),
+	}, nil
+}
+
+// route returns the backends of the provider: the ones listing it or the default ones if none of them lists it
+func (s *CloudInfoSource) route(provider string) []Backend {
+	var routed, defaults []Backend
+	for _, b := range s.backends {
+		if b.serves(provider) {
+			routed = append(routed, b)
+		} else if len(b.Providers) == 0 {
+			defaults = append(defaults, b)
+		}
+	}
+	if len(routed) > 0 {
+		return routed
+	}
+	return defaults
+}
+
+// each calls the function with the backends of the provider; the backends not serving the requested data are skipped,
+// the not found error is returned if none of them serves it
+func (s *CloudInfoSource) each(provider string, fn func(b Backend) error) error {
+	backends := s.route(provider)
+	if len(backends) == 0 {
+		return emperror.With(runtime.NewAPIError("no cloud info backend serves the provider", nil, http.StatusNotFound),
+			"cloud-info", "provider", provider)
+	}
+
+	var notFound error
+	served := false
+	for _, b := range backends {
+		err := fn(b)
+		switch {
+		case err == nil:
+			served = true
+		case recommender.NotFound(err):
+			s.log.Debug("cloud info backend does not serve the data", map[string]interface{}{"backend": b.Name, "provider": provider})
+			if notFound == nil {
+				notFound = err
+			}
+		default:
+			return emperror.With(err, "backend", b.Name)
+		}
+	}
+	if !served {
+		return notFound
+	}
+	return nil
+}
+
+// GetProductDetails retrieves the product details of the region from the backends of the provider
+func (s *CloudInfoSource) GetProductDetails(ctx context.Context, provider string, service string, region string) ([]recommender.VirtualMachine, error) {
+	var vms []recommender.VirtualMachine
+	types := make(map[string]bool)
+	err := s.each(provider, func(b Backend) error {
+		products, err := b.Source.GetProductDetails(ctx, provider, service, region)
+		for _, vm := range products {
+			if !types[vm.Type] {
+				types[vm.Type] = true
+				vms = append(vms, vm)
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return vms, nil
+}
+
+// GetRegions retrieves the regions of the service from the backends of the provider
+func (s *CloudInfoSource) GetRegions(ctx context.Context, provider, service string) ([]cloudinfo.Region, error) {
+	var regions []cloudinfo.Region
+	err := s.each(provider, func(b Backend) error {
+		r, err := b.Source.GetRegions(ctx, provider, service)
+		regions = mergeRegions(regions, r)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return regions, nil
+}
+
+// GetContinentsData retrieves the regions of the service grouped by continents from the backends of the provider
+func (s *CloudInfoSource) GetContinentsData(ctx context.Context, provider, service string) ([]cloudinfo.Continent, error) {
+	var continents []cloudinfo.Continent
+	err := s.each(provider, func(b Backend) error {
+		data, err := b.Source.GetContinentsData(ctx, provider, service)
+		for _, c := range data {
+			merged := false
+			for i := range continents {
+				if continents[i].Name == c.Name {
+					continents[i].Regions = mergeRegions(continents[i].Regions, c.Regions)
+					merged = true
+				}
+			}
+			if !merged {
+				continents = append(continents, cloudinfo.Continent{Name: c.Name, Regions: mergeRegions(nil, c.Regions)})
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return continents, nil
+}
+
+// GetZones retrieves the zones of the region from the backends of the provider
+func (s *CloudInfoSource) GetZones(ctx context.Context, provider, service, region string) ([]string, error) {
+	var zones []string
+	err := s.each(provider, func(b Backend) error {
+		z, err := b.Source.GetZones(ctx, provider, service, region)
+		zones = mergeStrings(zones, z)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return zones, nil
+}
+
+// GetContinents retrieves the supported continents of every backend
+func (s *CloudInfoSource) GetContinents(ctx context.Context) ([]string, error) {
+	var continents []string
+	for _, b := range s.backends {
+		c, err := b.Source.GetContinents(ctx)
+		if err != nil {
+			return nil, emperror.With(err, "backend", b.Name)
+		}
+		continents = mergeStrings(continents, c)
+	}
+	return continents, nil
+}
+
+// GetRegion retrieves the name of the region from the first backend of the provider serving it
+func (s *CloudInfoSource) GetRegion(ctx context.Context, provider string, service string, region string) (string, error) {
+	return s.first(provider, func(b Backend) (string, error) {
+		return b.Source.GetRegion(ctx, provider, service, region)
+	})
+}
+
+// GetProvider retrieves the provider from the first backend serving it
+func (s *CloudInfoSource) GetProvider(ctx context.Context, provider string) (string, error) {
+	return s.first(provider, func(b Backend) (string, error) {
+		return b.Source.GetProvider(ctx, provider)
+	})
+}
+
+// GetService retrieves the service from the first backend of the provider serving it
+func (s *CloudInfoSource) GetService(ctx context.Context, provider string, service string) (string, error) {
+	return s.first(provider, func(b Backend) (string, error) {
+		return b.Source.GetService(ctx, provider, service)
+	})
+}
+
+// GetImages retrieves the images of the region from the backends of the provider
+func (s *CloudInfoSource) GetImages(ctx context.Context, provider string, service string, region string) ([]cloudinfo.Image, error) {
+	var images []cloudinfo.Image
+	names := make(map[string]bool)
+	err := s.each(provider, func(b Backend) error {
+		i, err := b.Source.GetImages(ctx, provider, service, region)
+		for _, image := range i {
+			if !names[image.Name] {
+				names[image.Name] = true
+				images = append(images, image)
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+// GetVersions retrieves the Kubernetes versions of the region from the backends of the provider
+func (s *CloudInfoSource) GetVersions(ctx context.Context, provider string, service string, region string) ([]cloudinfo.LocationVersion, error) {
+	var versions []cloudinfo.LocationVersion
+	err := s.each(provider, func(b Backend) error {
+		v, err := b.Source.GetVersions(ctx, provider, service, region)
+		for _, lv := range v {
+			merged := false
+			for i := range versions {
+				if versions[i].Location == lv.Location {
+					versions[i].Versions = mergeStrings(versions[i].Versions, lv.Versions)
+					merged = true
+				}
+			}
+			if !merged {
+				versions = append(versions, cloudinfo.LocationVersion{Location: lv.Location, Default: lv.Default, Versions: mergeStrings(nil, lv.Versions)})
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// first returns the first non-empty response of the backends of the provider
+func (s *CloudInfoSource) first(provider string, fn func(b Backend) (string, error)) (string, error) {
+	var value string
+	err := s.each(provider, func(b Backend) error {
+		if value != "" {
+			return nil
+		}
+		v, err := fn(b)
+		value = v
+		return err
+	})
+	return value, err
+}
+
+// StaleProductDetails returns true if any of the backends of the provider served outdated product details
+func (s *CloudInfoSource) StaleProductDetails(provider string, service string, region string) bool {
+	for _, b := range s.route(provider) {
+		if source, ok := b.Source.(recommender.StaleDataSource); ok && source.StaleProductDetails(provider, service, region) {
+			return true
+		}
+	}
+	return false
+}
+
+func mergeRegions(regions []cloudinfo.Region, other []cloudinfo.Region) []cloudinfo.Region {
+	for _, r := range other {
+		found := false
+		for _, existing := range regions {
+			if existing.Id == r.Id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			regions = append(regions, r)
+		}
+	}
+	return regions
+}
+
+func mergeStrings(values []string, other []string) []string {
+	for _, v := range other {
+		found := false
+		for _, existing := range values {
+			if existing == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			values = append(values, v)
+		}
+	}
+	return values
+}
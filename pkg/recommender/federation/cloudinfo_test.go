@@ -0,0 +1,251 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package federation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/banzaicloud/telescopes/.gen/cloudinfo"
+	"github.com/banzaicloud/telescopes/pkg/recommender"
+	"github.com/go-openapi/runtime"
+	"github.com/goph/logur"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// staticSource serves the products, zones, images and versions of the regions it knows
+type staticSource struct {
+	products map[string][]recommender.VirtualMachine
+	zones    map[string][]string
+	images   map[string][]cloudinfo.Image
+	versions map[string][]cloudinfo.LocationVersion
+	err      error
+}
+
+func (s *staticSource) notFound() error {
+	return runtime.NewAPIError("not found", nil, http.StatusNotFound)
+}
+
+func (s *staticSource) GetProductDetails(ctx context.Context, provider string, service string, region string) ([]recommender.VirtualMachine, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if products, ok := s.products[region]; ok {
+		return products, nil
+	}
+	return nil, s.notFound()
+}
+
+func (s *staticSource) GetRegions(ctx context.Context, provider, service string) ([]cloudinfo.Region, error) {
+	var regions []cloudinfo.Region
+	for region := range s.zones {
+		regions = append(regions, cloudinfo.Region{Id: region, Name: region})
+	}
+	return regions, s.err
+}
+
+func (s *staticSource) GetContinentsData(ctx context.Context, provider, service string) ([]cloudinfo.Continent, error) {
+	regions, err := s.GetRegions(ctx, provider, service)
+	return []cloudinfo.Continent{{Name: "Europe", Regions: regions}}, err
+}
+
+func (s *staticSource) GetZones(ctx context.Context, provider, service, region string) ([]string, error) {
+	if zones, ok := s.zones[region]; ok {
+		return zones, s.err
+	}
+	return nil, s.notFound()
+}
+
+func (s *staticSource) GetContinents(ctx context.Context) ([]string, error) {
+	return []string{"Europe"}, s.err
+}
+
+func (s *staticSource) GetRegion(ctx context.Context, provider string, service string, region string) (string, error) {
+	if _, ok := s.zones[region]; ok {
+		return region, s.err
+	}
+	return "", s.notFound()
+}
+
+func (s *staticSource) GetProvider(ctx context.Context, provider string) (string, error) {
+	return provider, s.err
+}
+
+func (s *staticSource) GetService(ctx context.Context, provider string, service string) (string, error) {
+	return service, s.err
+}
+
+func (s *staticSource) GetImages(ctx context.Context, provider string, service string, region string) ([]cloudinfo.Image, error) {
+	if images, ok := s.images[region]; ok {
+		return images, s.err
+	}
+	return nil, s.notFound()
+}
+
+func (s *staticSource) GetVersions(ctx context.Context, provider string, service string, region string) ([]cloudinfo.LocationVersion, error) {
+	if versions, ok := s.versions[region]; ok {
+		return versions, s.err
+	}
+	return nil, s.notFound()
+}
+
+func TestCloudInfoSource(t *testing.T) {
+	public := &staticSource{
+		products: map[string][]recommender.VirtualMachine{
+			"eu-west-1": {{Type: "m5.large", OnDemandPrice: 0.107}},
+		},
+		zones:  map[string][]string{"eu-west-1": {"eu-west-1a", "eu-west-1b"}},
+		images: map[string][]cloudinfo.Image{"eu-west-1": {{Name: "ami-1", Version: "1.14.1"}}},
+		versions: map[string][]cloudinfo.LocationVersion{
+			"eu-west-1": {{Location: "eu-west-1", Default: "1.14.1", Versions: []string{"1.13.5", "1.14.1"}}},
+		},
+	}
+	partner := &staticSource{
+		products: map[string][]recommender.VirtualMachine{
+			"eu-west-1":    {{Type: "m5.large", OnDemandPrice: 0.09}, {Type: "c5.large", OnDemandPrice: 0.096}},
+			"eu-central-1": {{Type: "m5.large", OnDemandPrice: 0.1}},
+		},
+		zones:  map[string][]string{"eu-west-1": {"eu-west-1b", "eu-west-1c"}, "eu-central-1": {"eu-central-1a"}},
+		images: map[string][]cloudinfo.Image{"eu-west-1": {{Name: "ami-2", Version: "1.14.1", Gpu: true}, {Name: "ami-1", Version: "1.14.1"}}},
+		versions: map[string][]cloudinfo.LocationVersion{
+			"eu-west-1": {{Location: "eu-west-1", Default: "1.14.1", Versions: []string{"1.14.1", "1.15.0"}}},
+		},
+	}
+	private := &staticSource{
+		products: map[string][]recommender.VirtualMachine{
+			"dc1": {{Type: "bm.large", OnDemandPrice: 0.05}},
+		},
+		zones: map[string][]string{"dc1": {"dc1-rack1"}},
+	}
+
+	tests := []struct {
+		name     string
+		backends []Backend
+		check    func(s *CloudInfoSource)
+	}{
+		{
+			name: "providers are routed to their backends",
+			backends: []Backend{
+				{Name: "public", Source: public},
+				{Name: "private", Providers: []string{"onprem"}, Source: private},
+			},
+			check: func(s *CloudInfoSource) {
+				vms, err := s.GetProductDetails(context.Background(), "onprem", "compute", "dc1")
+				assert.NoError(t, err)
+				assert.Equal(t, []recommender.VirtualMachine{{Type: "bm.large", OnDemandPrice: 0.05}}, vms)
+
+				vms, err = s.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+				assert.NoError(t, err)
+				assert.Equal(t, []recommender.VirtualMachine{{Type: "m5.large", OnDemandPrice: 0.107}}, vms)
+
+				_, err = s.GetRegion(context.Background(), "amazon", "compute", "dc1")
+				assert.Error(t, err)
+			},
+		},
+		{
+			name: "responses of several backends are merged",
+			backends: []Backend{
+				{Name: "partner", Providers: []string{"amazon"}, Source: partner},
+				{Name: "public", Providers: []string{"amazon"}, Source: public},
+			},
+			check: func(s *CloudInfoSource) {
+				vms, err := s.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+				assert.NoError(t, err)
+				assert.Equal(t, []recommender.VirtualMachine{{Type: "m5.large", OnDemandPrice: 0.09}, {Type: "c5.large", OnDemandPrice: 0.096}}, vms)
+
+				zones, err := s.GetZones(context.Background(), "amazon", "compute", "eu-west-1")
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"eu-west-1b", "eu-west-1c", "eu-west-1a"}, zones)
+
+				// the region served by one of the backends only
+				vms, err = s.GetProductDetails(context.Background(), "amazon", "compute", "eu-central-1")
+				assert.NoError(t, err)
+				assert.Equal(t, 1, len(vms))
+
+				regions, err := s.GetRegions(context.Background(), "amazon", "compute")
+				assert.NoError(t, err)
+				assert.Equal(t, 2, len(regions))
+
+				continents, err := s.GetContinentsData(context.Background(), "amazon", "compute")
+				assert.NoError(t, err)
+				assert.Equal(t, 1, len(continents))
+				assert.Equal(t, 2, len(continents[0].Regions))
+
+				images, err := s.GetImages(context.Background(), "amazon", "compute", "eu-west-1")
+				assert.NoError(t, err)
+				assert.Equal(t, []cloudinfo.Image{{Name: "ami-2", Version: "1.14.1", Gpu: true}, {Name: "ami-1", Version: "1.14.1"}}, images)
+
+				versions, err := s.GetVersions(context.Background(), "amazon", "compute", "eu-west-1")
+				assert.NoError(t, err)
+				assert.Equal(t, []cloudinfo.LocationVersion{
+					{Location: "eu-west-1", Default: "1.14.1", Versions: []string{"1.14.1", "1.15.0", "1.13.5"}},
+				}, versions)
+			},
+		},
+		{
+			name: "failure of a backend fails the merged call",
+			backends: []Backend{
+				{Name: "public", Providers: []string{"amazon"}, Source: public},
+				{Name: "broken", Providers: []string{"amazon"}, Source: &staticSource{err: errors.New("connection refused")}},
+			},
+			check: func(s *CloudInfoSource) {
+				_, err := s.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+				assert.Error(t, err)
+			},
+		},
+		{
+			name: "client error of a backend fails the merged call",
+			backends: []Backend{
+				{Name: "public", Providers: []string{"amazon"}, Source: public},
+				{Name: "unauthorized", Providers: []string{"amazon"},
+					Source: &staticSource{err: runtime.NewAPIError("unauthorized", nil, http.StatusUnauthorized)}},
+			},
+			check: func(s *CloudInfoSource) {
+				_, err := s.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+				assert.Error(t, err)
+				assert.Equal(t, http.StatusUnauthorized, errors.Cause(err).(*runtime.APIError).Code)
+			},
+		},
+		{
+			name: "provider without backend",
+			backends: []Backend{
+				{Name: "private", Providers: []string{"onprem"}, Source: private},
+			},
+			check: func(s *CloudInfoSource) {
+				_, err := s.GetProvider(context.Background(), "amazon")
+				assert.Error(t, err)
+				assert.Equal(t, http.StatusNotFound, errors.Cause(err).(*runtime.APIError).Code)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test // scopelint
+		t.Run(test.name, func(t *testing.T) {
+			s, err := NewCloudInfoSource(test.backends, logur.NewTestLogger())
+			assert.NoError(t, err)
+			test.check(s)
+		})
+	}
+}
+
+func TestNewCloudInfoSource(t *testing.T) {
+	_, err := NewCloudInfoSource(nil, logur.NewTestLogger())
+	assert.Error(t, err)
+
+	_, err = NewCloudInfoSource([]Backend{{Name: "public"}, {Name: "public"}}, logur.NewTestLogger())
+	assert.Error(t, err)
+}
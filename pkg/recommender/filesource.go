@@ -0,0 +1,262 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/banzaicloud/telescopes/.gen/cloudinfo"
+	"github.com/go-openapi/runtime"
+	"github.com/goph/emperror"
+	"github.com/goph/logur"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Snapshot files of the cloud info data, relative to the snapshot directory; the files are JSON or YAML documents
+// with .json, .yaml or .yml extension holding the responses of the related cloud info API calls
+const (
+	// supported continents: ["Asia", "Europe", ...]
+	SnapshotContinentsFile = "continents"
+	// providers and their services
+	SnapshotProvidersFile = "providers"
+	// regions of a service: <provider>/<service>/regions
+	SnapshotRegionsFile = "regions"
+	// regions of a service grouped by continents: <provider>/<service>/continents
+	SnapshotContinentsDataFile = "continents"
+	// name and zones of a region: <provider>/<service>/<region>/region
+	SnapshotRegionFile = "region"
+	// product details of a region: <provider>/<service>/<region>/products
+	SnapshotProductsFile = "products"
+	// images of a region: <provider>/<service>/<region>/images
+	SnapshotImagesFile = "images"
+	// Kubernetes versions of a region: <provider>/<service>/<region>/versions
+	SnapshotVersionsFile = "versions"
+)
+
+// snapshotExtensions holds the supported snapshot file extensions in the order of precedence
+var snapshotExtensions = []string{".json", ".yaml", ".yml"}
+
+// fileCloudInfoSource is a CloudInfoSource reading the cloud info data from a snapshot directory
+type fileCloudInfoSource struct {
+	dir    string
+	logger logur.Logger
+}
+
+// NewFileCloudInfoSource creates a cloud info source reading the snapshot in the directory
+func NewFileCloudInfoSource(dir string, logger logur.Logger) (CloudInfoSource, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, emperror.Wrap(err, "failed to open cloud info snapshot")
+	}
+	if !info.IsDir() {
+		return nil, errors.Errorf("cloud info snapshot %s is not a directory", dir)
+	}
+
+	return &fileCloudInfoSource{
+		dir:    dir,
+		logger: logur.WithFields(logger, map[string]interface{}{"cli": "cloud-info-snapshot"}),
+	}, nil
+}
+
+// GetProductDetails reads the product details of the region from the snapshot
+func (s *fileCloudInfoSource) GetProductDetails(ctx context.Context, provider string, service string, region string) ([]VirtualMachine, error) {
+	var products cloudinfo.ProductDetailsResponse
+	if err := s.read(&products, provider, service, region, SnapshotProductsFile); err != nil {
+		return nil, err
+	}
+
+	vms := make([]VirtualMachine, 0, len(products.Products))
+	for _, p := range products.Products {
+		vms = append(vms, newVirtualMachine(p))
+	}
+	return vms, nil
+}
+
+// GetRegions reads the regions of the service from the snapshot
+func (s *fileCloudInfoSource) GetRegions(ctx context.Context, provider, service string) ([]cloudinfo.Region, error) {
+	var regions []cloudinfo.Region
+	if err := s.read(&regions, provider, service, SnapshotRegionsFile); err != nil {
+		return nil, err
+	}
+	return regions, nil
+}
+
+// GetContinentsData reads the regions of the service grouped by continents from the snapshot
+func (s *fileCloudInfoSource) GetContinentsData(ctx context.Context, provider, service string) ([]cloudinfo.Continent, error) {
+	var continents []cloudinfo.Continent
+	if err := s.read(&continents, provider, service, SnapshotContinentsDataFile); err != nil {
+		return nil, err
+	}
+	return continents, nil
+}
+
+// GetZones reads the zones of the region from the snapshot
+func (s *fileCloudInfoSource) GetZones(ctx context.Context, provider, service, region string) ([]string, error) {
+	var r cloudinfo.GetRegionResp
+	if err := s.read(&r, provider, service, region, SnapshotRegionFile); err != nil {
+		return nil, err
+	}
+	return r.Zones, nil
+}
+
+// GetContinents reads the supported continents from the snapshot
+func (s *fileCloudInfoSource) GetContinents(ctx context.Context) ([]string, error) {
+	var continents []string
+	if err := s.read(&continents, SnapshotContinentsFile); err != nil {
+		return nil, err
+	}
+	return continents, nil
+}
+
+// GetRegion reads the name of the region from the snapshot
+func (s *fileCloudInfoSource) GetRegion(ctx context.Context, provider string, service string, region string) (string, error) {
+	var r cloudinfo.GetRegionResp
+	if err := s.read(&r, provider, service, region, SnapshotRegionFile); err != nil {
+		return "", err
+	}
+	return r.Name, nil
+}
+
+// GetProvider checks whether the provider is in the snapshot
+func (s *fileCloudInfoSource) GetProvider(ctx context.Context, provider string) (string, error) {
+	p, err := s.provider(provider)
+	if err != nil {
+		return "", err
+	}
+	return p.Provider, nil
+}
+
+// GetService checks whether the service of the provider is in the snapshot
+func (s *fileCloudInfoSource) GetService(ctx context.Context, provider string, service string) (string, error) {
+	p, err := s.provider(provider)
+	if err != nil {
+		return "", err
+	}
+	for _, svc := range p.Services {
+		if svc.Service == service {
+			return svc.Service, nil
+		}
+	}
+	return "", notInSnapshot(fmt.Sprintf("service %s of provider %s", service, provider))
+}
+
+// GetImages reads the images of the region from the snapshot
+func (s *fileCloudInfoSource) GetImages(ctx context.Context, provider string, service string, region string) ([]cloudinfo.Image, error) {
+	var images cloudinfo.ImagesResponse
+	if err := s.read(&images, provider, service, region, SnapshotImagesFile); err != nil {
+		return nil, err
+	}
+	return images.Images, nil
+}
+
+// GetVersions reads the Kubernetes versions of the region from the snapshot
+func (s *fileCloudInfoSource) GetVersions(ctx context.Context, provider string, service string, region string) ([]cloudinfo.LocationVersion, error) {
+	var versions cloudinfo.VersionsResponse
+	if err := s.read(&versions, provider, service, region, SnapshotVersionsFile); err != nil {
+		return nil, err
+	}
+	return versions.Versions, nil
+}
+
+// provider looks up the provider in the snapshot
+func (s *fileCloudInfoSource) provider(provider string) (*cloudinfo.Provider, error) {
+	var providers cloudinfo.ProvidersResponse
+	if err := s.read(&providers, SnapshotProvidersFile); err != nil {
+		return nil, err
+	}
+	for _, p := range providers.Providers {
+		if p.Provider == provider {
+			return &p, nil
+		}
+	}
+	return nil, notInSnapshot(fmt.Sprintf("provider %s", provider))
+}
+
+// read decodes the snapshot file at the path into the value
+func (s *fileCloudInfoSource) read(value interface{}, path ...string) error {
+	err := ReadSnapshotFile(s.dir, value, path...)
+	if err != nil {
+		s.logger.Debug("failed to read snapshot file", map[string]interface{}{"path": filepath.Join(path...), "err": err.Error()})
+	}
+	return err
+}
+
+// ReadSnapshotFile decodes the snapshot file at the path relative to the snapshot directory into the value, a missing
+// file results in a not found cloud info error
+func ReadSnapshotFile(dir string, value interface{}, path ...string) error {
+	base := filepath.Join(append([]string{dir}, path...)...)
+	for _, ext := range snapshotExtensions {
+		data, err := ioutil.ReadFile(base + ext)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return emperror.With(emperror.Wrap(err, "failed to read cloud info snapshot file"), "file", base+ext)
+		}
+
+		if ext != ".json" {
+			if data, err = yamlToJSON(data); err != nil {
+				return emperror.With(emperror.Wrap(err, "failed to convert cloud info snapshot file"), "file", base+ext)
+			}
+		}
+		if err := json.Unmarshal(data, value); err != nil {
+			return emperror.With(emperror.Wrap(err, "failed to decode cloud info snapshot file"), "file", base+ext)
+		}
+		return nil
+	}
+
+	return notInSnapshot(filepath.Join(path...))
+}
+
+// notInSnapshot returns the error of the cloud info data missing from the snapshot; it's classified as the not found
+// response of the cloud info service
+func notInSnapshot(what string) error {
+	return emperror.With(runtime.NewAPIError(fmt.Sprintf("%s not found in the cloud info snapshot", what), nil, http.StatusNotFound), cloudInfoService)
+}
+
+// yamlToJSON converts a YAML document to JSON, so that it can be decoded with the JSON field names of the cloud info models
+func yamlToJSON(data []byte) ([]byte, error) {
+	var value interface{}
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonCompatible(value))
+}
+
+// jsonCompatible replaces the maps with interface keys decoded from YAML with maps with string keys
+func jsonCompatible(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			m[fmt.Sprintf("%v", key)] = jsonCompatible(val)
+		}
+		return m
+	case []interface{}:
+		for i, val := range v {
+			v[i] = jsonCompatible(val)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/banzaicloud/telescopes/.gen/cloudinfo"
+	"github.com/go-openapi/runtime"
+	"github.com/goph/logur"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// snapshotFiles holds a minimal cloud info snapshot
+var snapshotFiles = map[string]string{
+	"continents.json":                           `["Europe"]`,
+	"providers.yaml":                            "providers:\n- provider: amazon\n  services:\n  - service: compute\n  - service: eks\n",
+	"amazon/compute/regions.json":               `[{"id": "eu-west-1", "name": "EU (Ireland)"}]`,
+	"amazon/compute/continents.json":            `[{"name": "Europe", "regions": [{"id": "eu-west-1", "name": "EU (Ireland)"}]}]`,
+	"amazon/compute/eu-west-1/region.yml":       "id: eu-west-1\nname: EU (Ireland)\nzones: [eu-west-1a, eu-west-1b]\n",
+	"amazon/compute/eu-west-1/products.json":    `{"products": [{"type": "m5.large", "cpusPerVm": 2, "memPerVm": 8, "onDemandPrice": 0.107, "spotPrice": [{"zone": "eu-west-1a", "price": 0.03}]}]}`,
+	"amazon/compute/eu-central-1/products.yaml": "products:\n- type: m5.large\n  cpusPerVm: 2\n  memPerVm: 8\n  onDemandPrice: 0.115\n  attributes:\n    storage: EBS only\n",
+	"amazon/eks/eu-west-1/images.json":          `{"images": [{"name": "ami-0c7388116d474ee10", "version": "1.14", "gpu": false}]}`,
+	"amazon/eks/eu-west-1/versions.json":        `{"versions": [{"location": "eu-west-1", "default": "1.14", "versions": ["1.13", "1.14"]}]}`,
+}
+
+func TestFileCloudInfoSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	for name, content := range snapshotFiles {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+
+	source, err := NewFileCloudInfoSource(dir, logur.NewTestLogger())
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		check func(source CloudInfoSource)
+	}{
+		{
+			name: "continents",
+			check: func(source CloudInfoSource) {
+				continents, err := source.GetContinents(context.Background())
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"Europe"}, continents)
+
+				data, err := source.GetContinentsData(context.Background(), "amazon", "compute")
+				assert.NoError(t, err)
+				assert.Equal(t, []cloudinfo.Continent{{Name: "Europe", Regions: []cloudinfo.Region{{Id: "eu-west-1", Name: "EU (Ireland)"}}}}, data)
+			},
+		},
+		{
+			name: "providers and services",
+			check: func(source CloudInfoSource) {
+				provider, err := source.GetProvider(context.Background(), "amazon")
+				assert.NoError(t, err)
+				assert.Equal(t, "amazon", provider)
+
+				service, err := source.GetService(context.Background(), "amazon", "eks")
+				assert.NoError(t, err)
+				assert.Equal(t, "eks", service)
+
+				_, err = source.GetService(context.Background(), "amazon", "ack")
+				assert.IsType(t, &runtime.APIError{}, errors.Cause(err))
+				_, err = source.GetProvider(context.Background(), "alibaba")
+				assert.IsType(t, &runtime.APIError{}, errors.Cause(err))
+			},
+		},
+		{
+			name: "regions and zones",
+			check: func(source CloudInfoSource) {
+				regions, err := source.GetRegions(context.Background(), "amazon", "compute")
+				assert.NoError(t, err)
+				assert.Equal(t, []cloudinfo.Region{{Id: "eu-west-1", Name: "EU (Ireland)"}}, regions)
+
+				region, err := source.GetRegion(context.Background(), "amazon", "compute", "eu-west-1")
+				assert.NoError(t, err)
+				assert.Equal(t, "EU (Ireland)", region)
+
+				zones, err := source.GetZones(context.Background(), "amazon", "compute", "eu-west-1")
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"eu-west-1a", "eu-west-1b"}, zones)
+
+				_, err = source.GetRegion(context.Background(), "amazon", "compute", "us-east-1")
+				assert.IsType(t, &runtime.APIError{}, errors.Cause(err))
+			},
+		},
+		{
+			name: "products",
+			check: func(source CloudInfoSource) {
+				vms, err := source.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+				assert.NoError(t, err)
+				assert.Equal(t, []VirtualMachine{{Type: "m5.large", Cpus: 2, Mem: 8, OnDemandPrice: 0.107, AvgPrice: 0.03}}, vms)
+
+				vms, err = source.GetProductDetails(context.Background(), "amazon", "compute", "eu-central-1")
+				assert.NoError(t, err)
+				assert.Equal(t, 0.115, vms[0].OnDemandPrice)
+			},
+		},
+		{
+			name: "images and versions",
+			check: func(source CloudInfoSource) {
+				images, err := source.GetImages(context.Background(), "amazon", "eks", "eu-west-1")
+				assert.NoError(t, err)
+				assert.Equal(t, []cloudinfo.Image{{Name: "ami-0c7388116d474ee10", Version: "1.14"}}, images)
+
+				versions, err := source.GetVersions(context.Background(), "amazon", "eks", "eu-west-1")
+				assert.NoError(t, err)
+				assert.Equal(t, []cloudinfo.LocationVersion{{Location: "eu-west-1", Default: "1.14", Versions: []string{"1.13", "1.14"}}}, versions)
+
+				_, err = source.GetImages(context.Background(), "amazon", "compute", "eu-west-1")
+				assert.IsType(t, &runtime.APIError{}, errors.Cause(err))
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			test.check(source)
+		})
+	}
+}
+
+func TestNewFileCloudInfoSource_missingDir(t *testing.T) {
+	_, err := NewFileCloudInfoSource(filepath.Join(os.TempDir(), "missing-snapshot"), logur.NewTestLogger())
+	assert.Error(t, err)
+}
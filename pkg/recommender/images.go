@@ -0,0 +1,121 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"context"
+
+	"github.com/banzaicloud/telescopes/.gen/cloudinfo"
+	"github.com/goph/emperror"
+	"github.com/pkg/errors"
+)
+
+// nodeImages holds the names of the node images of a Kubernetes version by GPU support
+type nodeImages map[bool]string
+
+// kubernetesImages checks that the Kubernetes version is offered in the location and returns its node images; the
+// version isn't checked if cloud info has no version data of the region, nil is returned if it has no image data of
+// the region, in this case the instance types are not filtered by images
+func (e *Engine) kubernetesImages(ctx context.Context, provider, service, region, zone, version string) (nodeImages, error) {
+	versions, err := e.ciSource.GetVersions(ctx, provider, service, region)
+	switch {
+	case err != nil && !NotFound(err):
+		return nil, err
+	case len(versions) == 0:
+		e.log.Debug("no version data available, the kubernetes version is not checked", map[string]interface{}{"region": region})
+	case !versionOffered(versions, region, zone, version):
+		return nil, emperror.With(errors.Errorf("kubernetes version %s is not offered in region %s", version, region),
+			RecommenderErrorTag, "version", version)
+	}
+
+	images, err := e.ciSource.GetImages(ctx, provider, service, region)
+	if err != nil && !NotFound(err) {
+		return nil, err
+	}
+	if len(images) == 0 {
+		e.log.Debug("no image data available, the instance types are not filtered by images", map[string]interface{}{"region": region})
+		return nil, nil
+	}
+
+	versionImages := make(nodeImages)
+	for _, image := range images {
+		if image.Version != "" && image.Version != version {
+			continue
+		}
+		if _, ok := versionImages[image.Gpu]; !ok {
+			versionImages[image.Gpu] = image.Name
+		}
+	}
+	if len(versionImages) == 0 {
+		return nil, emperror.With(errors.Errorf("no node image for kubernetes version %s in region %s", version, region),
+			RecommenderErrorTag, "version", version)
+	}
+	return versionImages, nil
+}
+
+// versionOffered checks whether the version is offered in the zone or the region; the versions of every location are
+// considered if none of them belongs to the zone or the region
+func versionOffered(versions []cloudinfo.LocationVersion, region, zone, version string) bool {
+	var located []cloudinfo.LocationVersion
+	for _, lv := range versions {
+		if lv.Location == region || (zone != "" && lv.Location == zone) {
+			located = append(located, lv)
+		}
+	}
+	if len(located) == 0 {
+		located = versions
+	}
+
+	for _, lv := range located {
+		for _, v := range lv.Versions {
+			if v == version {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// image returns the name of the node image suitable for the instance type
+func (images nodeImages) image(vm VirtualMachine) (string, bool) {
+	name, ok := images[vm.Gpus > 0]
+	return name, ok
+}
+
+// imageProducts returns the products having a node image; GPU instance types require a GPU image
+func imageProducts(products []VirtualMachine, images nodeImages) []VirtualMachine {
+	if images == nil {
+		return products
+	}
+	var withImage []VirtualMachine
+	for _, vm := range products {
+		if _, ok := images.image(vm); ok {
+			withImage = append(withImage, vm)
+		}
+	}
+	return withImage
+}
+
+// withImages sets the node images of the node pools
+func withImages(nodePools []NodePool, images nodeImages) []NodePool {
+	nps := make([]NodePool, len(nodePools))
+	for i, np := range nodePools {
+		nps[i] = np
+		if name, ok := images.image(np.VmType); ok {
+			nps[i].Image = name
+		}
+	}
+	return nps
+}
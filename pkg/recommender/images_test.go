@@ -0,0 +1,198 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/banzaicloud/telescopes/.gen/cloudinfo"
+	"github.com/go-openapi/runtime"
+	"github.com/goph/emperror"
+	"github.com/goph/logur"
+	"github.com/stretchr/testify/assert"
+)
+
+// imageSource serves the listed images and versions, the missing data is not found
+type imageSource struct {
+	dummyProducts
+	images   []cloudinfo.Image
+	versions []cloudinfo.LocationVersion
+}
+
+func (s *imageSource) GetImages(ctx context.Context, provider string, service string, region string) ([]cloudinfo.Image, error) {
+	if s.images == nil {
+		return nil, runtime.NewAPIError("images not found", nil, http.StatusNotFound)
+	}
+	return s.images, nil
+}
+
+func (s *imageSource) GetVersions(ctx context.Context, provider string, service string, region string) ([]cloudinfo.LocationVersion, error) {
+	if s.versions == nil {
+		return nil, runtime.NewAPIError("versions not found", nil, http.StatusNotFound)
+	}
+	return s.versions, nil
+}
+
+func Test_versionOffered(t *testing.T) {
+	versions := []cloudinfo.LocationVersion{
+		{Location: "westeurope", Versions: []string{"1.13.5", "1.14.1"}},
+		{Location: "northeurope", Versions: []string{"1.12.7"}},
+	}
+	tests := []struct {
+		name     string
+		versions []cloudinfo.LocationVersion
+		region   string
+		zone     string
+		version  string
+		offered  bool
+	}{
+		{
+			name:     "version offered in the region",
+			versions: versions,
+			region:   "westeurope",
+			version:  "1.14.1",
+			offered:  true,
+		},
+		{
+			name:     "version offered in another region only",
+			versions: versions,
+			region:   "westeurope",
+			version:  "1.12.7",
+			offered:  false,
+		},
+		{
+			name:     "version offered in the zone",
+			versions: []cloudinfo.LocationVersion{{Location: "europe-west1-b", Versions: []string{"1.14.1"}}},
+			region:   "europe-west1",
+			zone:     "europe-west1-b",
+			version:  "1.14.1",
+			offered:  true,
+		},
+		{
+			name:     "versions of unknown locations are considered",
+			versions: []cloudinfo.LocationVersion{{Location: "eu-west-1a", Versions: []string{"1.13.5"}}},
+			region:   "eu-west-1",
+			version:  "1.13.5",
+			offered:  true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.offered, versionOffered(test.versions, test.region, test.zone, test.version))
+		})
+	}
+}
+
+func Test_imageProducts(t *testing.T) {
+	products := []VirtualMachine{
+		{Type: "m5.large", Cpus: 2, Mem: 8},
+		{Type: "p3.2xlarge", Cpus: 8, Mem: 61, Gpus: 1},
+	}
+	tests := []struct {
+		name   string
+		images nodeImages
+		check  func(vms []VirtualMachine, nps []NodePool)
+	}{
+		{
+			name:   "gpu instance types require a gpu image",
+			images: nodeImages{false: "ubuntu-1.14"},
+			check: func(vms []VirtualMachine, nps []NodePool) {
+				assert.Equal(t, []VirtualMachine{products[0]}, vms)
+				assert.Equal(t, "ubuntu-1.14", nps[0].Image)
+				assert.Equal(t, "", nps[1].Image)
+			},
+		},
+		{
+			name:   "gpu and non-gpu images",
+			images: nodeImages{false: "ubuntu-1.14", true: "ubuntu-gpu-1.14"},
+			check: func(vms []VirtualMachine, nps []NodePool) {
+				assert.Equal(t, products, vms)
+				assert.Equal(t, "ubuntu-gpu-1.14", nps[1].Image)
+			},
+		},
+		{
+			name: "products are not filtered without image data",
+			check: func(vms []VirtualMachine, nps []NodePool) {
+				assert.Equal(t, products, vms)
+				assert.Equal(t, "", nps[0].Image)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			nps := withImages([]NodePool{{VmType: products[0]}, {VmType: products[1]}}, test.images)
+			test.check(imageProducts(products, test.images), nps)
+		})
+	}
+}
+
+func TestEngine_kubernetesImages(t *testing.T) {
+	versions := []cloudinfo.LocationVersion{{Location: "eu-west-1", Versions: []string{"1.13.5", "1.14.1"}}}
+	tests := []struct {
+		name   string
+		source *imageSource
+		check  func(images nodeImages, err error)
+	}{
+		{
+			name: "images of the version",
+			source: &imageSource{
+				versions: versions,
+				images:   []cloudinfo.Image{{Name: "ubuntu-1.13", Version: "1.13.5"}, {Name: "ubuntu-1.14", Version: "1.14.1"}},
+			},
+			check: func(images nodeImages, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, nodeImages{false: "ubuntu-1.14"}, images)
+			},
+		},
+		{
+			name:   "missing version and image data",
+			source: &imageSource{},
+			check: func(images nodeImages, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Nil(t, images)
+			},
+		},
+		{
+			name:   "empty version and image data",
+			source: &imageSource{versions: []cloudinfo.LocationVersion{}, images: []cloudinfo.Image{}},
+			check: func(images nodeImages, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Nil(t, images)
+			},
+		},
+		{
+			name: "no image of the version",
+			source: &imageSource{
+				versions: versions,
+				images:   []cloudinfo.Image{{Name: "ubuntu-1.13", Version: "1.13.5"}},
+			},
+			check: func(images nodeImages, err error) {
+				assert.EqualError(t, err, "no node image for kubernetes version 1.14.1 in region eu-west-1")
+				assert.Contains(t, emperror.Context(err), RecommenderErrorTag)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			engine := NewEngine(logur.NewTestLogger(), test.source, &dummyVms{}, &dummyNodePools{})
+			test.check(engine.kubernetesImages(context.Background(), "amazon", "eks", "eu-west-1", "", "1.14.1"))
+		})
+	}
+}
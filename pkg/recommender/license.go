@@ -0,0 +1,57 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+// licensedProducts returns a copy of the products with the license price of the operating system included in their prices,
+// so that the instance types are ranked by their total price
+func licensedProducts(products []VirtualMachine, os string) []VirtualMachine {
+	licensed := make([]VirtualMachine, len(products))
+	for i, vm := range products {
+		licensed[i] = vm
+		if os != OsWindows {
+			continue
+		}
+		if vm.OnDemandPrice > 0 {
+			licensed[i].OnDemandPrice += vm.WindowsLicensePrice
+		}
+		if vm.AvgPrice > 0 {
+			licensed[i].AvgPrice += vm.WindowsLicensePrice
+		}
+	}
+	return licensed
+}
+
+// withOs sets the operating system of the worker node pools
+func withOs(nodePools []NodePool, os string) []NodePool {
+	nps := make([]NodePool, len(nodePools))
+	for i, np := range nodePools {
+		nps[i] = np
+		if np.Role == Worker {
+			nps[i].Os = os
+		}
+	}
+	return nps
+}
+
+// licensePrice returns the amount of the operating system license prices of the node pools
+func licensePrice(nodePools []NodePool) float64 {
+	var sumPrice float64
+	for _, np := range nodePools {
+		withoutLicense := np
+		withoutLicense.Os = ""
+		sumPrice += np.PoolPrice() - withoutLicense.PoolPrice()
+	}
+	return sumPrice
+}
@@ -0,0 +1,69 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_licensedProducts(t *testing.T) {
+	products := []VirtualMachine{
+		{Type: "m5.large", OnDemandPrice: 0.096, AvgPrice: 0.04, WindowsLicensePrice: 0.092},
+		{Type: "m4.large", OnDemandPrice: 0.1, WindowsLicensePrice: 0.092},
+	}
+	tests := []struct {
+		name  string
+		os    string
+		check func(licensed []VirtualMachine)
+	}{
+		{
+			name: "linux prices are unchanged",
+			os:   OsLinux,
+			check: func(licensed []VirtualMachine) {
+				assert.Equal(t, products, licensed)
+			},
+		},
+		{
+			name: "windows license is included in the prices",
+			os:   OsWindows,
+			check: func(licensed []VirtualMachine) {
+				assert.InDelta(t, 0.188, licensed[0].OnDemandPrice, 1e-9)
+				assert.InDelta(t, 0.132, licensed[0].AvgPrice, 1e-9)
+				assert.Equal(t, float64(0), licensed[1].AvgPrice, "missing spot price should not be set")
+				assert.Equal(t, 0.096, products[0].OnDemandPrice, "the products should not be changed")
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			test.check(licensedProducts(products, test.os))
+		})
+	}
+}
+
+func Test_licensePrice(t *testing.T) {
+	nodePools := withOs([]NodePool{
+		{VmType: VirtualMachine{OnDemandPrice: 0.1, WindowsLicensePrice: 0.05}, SumNodes: 2, VmClass: Regular, Role: Worker},
+		{VmType: VirtualMachine{AvgPrice: 0.03, WindowsLicensePrice: 0.05}, SumNodes: 1, VmClass: Spot, Role: Worker},
+		{VmType: VirtualMachine{OnDemandPrice: 0.1, WindowsLicensePrice: 0.05}, SumNodes: 1, VmClass: Regular, Role: Master},
+	}, OsWindows)
+
+	assert.Equal(t, "", nodePools[2].Os, "master nodes should run linux")
+	assert.InDelta(t, 0.3, nodePools[0].PoolPrice(), 1e-9)
+	assert.InDelta(t, 0.15, licensePrice(nodePools), 1e-9)
+}
@@ -0,0 +1,109 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maxpods
+
+import "strings"
+
+const (
+	amazon = "amazon"
+	google = "google"
+	azure  = "azure"
+
+	// default pod limit of GKE nodes
+	googleMaxPods = 110
+	// default pod limit of AKS nodes with Azure CNI networking
+	azureMaxPods = 30
+)
+
+// eniLimit holds the number of network interfaces and the IPv4 addresses per interface of an EC2 instance size
+type eniLimit struct {
+	enis      int
+	ipsPerEni int
+}
+
+// amazonSizes holds the network interface limits of the current generation EC2 instance sizes
+var amazonSizes = map[string]eniLimit{
+	"nano":     {2, 2},
+	"micro":    {2, 2},
+	"small":    {3, 4},
+	"medium":   {3, 6},
+	"large":    {3, 10},
+	"xlarge":   {4, 15},
+	"2xlarge":  {4, 15},
+	"4xlarge":  {8, 30},
+	"8xlarge":  {8, 30},
+	"9xlarge":  {8, 30},
+	"10xlarge": {8, 30},
+	"12xlarge": {8, 30},
+	"16xlarge": {15, 50},
+	"18xlarge": {15, 50},
+	"24xlarge": {15, 50},
+	"metal":    {15, 50},
+}
+
+// Override sets the pod limit of an instance type that differs from the provider's model
+type Override struct {
+	Provider     string
+	InstanceType string
+	MaxPods      int
+}
+
+// Table is the built-in model of the maximum number of pods per node
+type Table struct {
+	// pod limits per provider and instance type that take precedence over the model
+	overrides map[string]map[string]int
+}
+
+// NewTable creates a max pods table with the provided overrides
+func NewTable(overrides []Override) *Table {
+	t := &Table{
+		overrides: make(map[string]map[string]int),
+	}
+	for _, o := range overrides {
+		if t.overrides[o.Provider] == nil {
+			t.overrides[o.Provider] = make(map[string]int)
+		}
+		t.overrides[o.Provider][o.InstanceType] = o.MaxPods
+	}
+	return t
+}
+
+// MaxPods returns the maximum number of pods on a node of the instance type, false if it's unknown;
+// on amazon the limit comes from the VPC CNI plugin: one IP address per pod, except the primary address of each network interface
+func (t *Table) MaxPods(provider, instanceType string) (int, bool) {
+	if maxPods, ok := t.overrides[provider][instanceType]; ok {
+		return maxPods, true
+	}
+
+	switch provider {
+	case amazon:
+		parts := strings.SplitN(instanceType, ".", 2)
+		if len(parts) != 2 {
+			return 0, false
+		}
+		limit, ok := amazonSizes[parts[1]]
+		if !ok {
+			return 0, false
+		}
+		// the pods using the host network (aws-node, kube-proxy) do not need an address
+		return limit.enis*(limit.ipsPerEni-1) + 2, true
+	case google:
+		return googleMaxPods, true
+	case azure:
+		return azureMaxPods, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maxpods
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable_MaxPods(t *testing.T) {
+	tests := []struct {
+		name         string
+		provider     string
+		instanceType string
+		check        func(maxPods int, ok bool)
+	}{
+		{
+			name:         "amazon instance type",
+			provider:     "amazon",
+			instanceType: "m5.large",
+			check: func(maxPods int, ok bool) {
+				assert.True(t, ok)
+				assert.Equal(t, 29, maxPods)
+			},
+		},
+		{
+			name:         "amazon instance type overridden",
+			provider:     "amazon",
+			instanceType: "t3.large",
+			check: func(maxPods int, ok bool) {
+				assert.True(t, ok)
+				assert.Equal(t, 35, maxPods)
+			},
+		},
+		{
+			name:         "unknown amazon instance size",
+			provider:     "amazon",
+			instanceType: "x1e.32xlarge",
+			check: func(maxPods int, ok bool) {
+				assert.False(t, ok)
+			},
+		},
+		{
+			name:         "google default",
+			provider:     "google",
+			instanceType: "n1-standard-2",
+			check: func(maxPods int, ok bool) {
+				assert.True(t, ok)
+				assert.Equal(t, 110, maxPods)
+			},
+		},
+		{
+			name:         "unknown provider",
+			provider:     "alibaba",
+			instanceType: "ecs.g5.large",
+			check: func(maxPods int, ok bool) {
+				assert.False(t, ok)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			table := NewTable([]Override{{Provider: "amazon", InstanceType: "t3.large", MaxPods: 35}})
+			test.check(table.MaxPods(test.provider, test.instanceType))
+		})
+	}
+}
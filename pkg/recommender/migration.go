@@ -0,0 +1,408 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// RecommendClusterMigration plans the migration of the actual layout to a cheaper layout with the requested resources
+func (e *Engine) RecommendClusterMigration(ctx context.Context, provider string, service string, region string, req ClusterMigrationReq) (*ClusterMigrationResp, error) {
+	e.log.Info(fmt.Sprintf("recommending cluster migration. request: [%#v]", req))
+
+	current, err := e.QuoteCluster(ctx, provider, service, region, ClusterQuoteReq{Zone: req.Zone, Os: req.Os, Layout: req.ActualLayout})
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := e.migrationTarget(ctx, provider, service, region, req, current)
+	if err != nil {
+		return nil, err
+	}
+
+	costs, err := e.clusterCosts(ctx, provider, service, region, req.Zone)
+	if err != nil {
+		return nil, err
+	}
+
+	planner := newMigrationPlanner(current.NodePools, target.NodePools, req.SumCpu, req.SumMem, costs)
+	steps := planner.plan()
+
+	e.log.Debug(fmt.Sprintf("planned migration in [%d] steps", len(steps)))
+
+	return &ClusterMigrationResp{
+		Current:  *current,
+		Target:   *target,
+		Steps:    steps,
+		Complete: planner.complete(),
+	}, nil
+}
+
+// migrationTarget keeps the node pools of the actual layout that the request allows and that are not more expensive
+// than the ones recommended from scratch, and scales them out to the requested resources with the constraints of the
+// request; the layout recommended from scratch is the target if it's cheaper, if none of the node pools are kept, if
+// they can't be scaled out or if the scaled out layout has fewer or more nodes than requested
+func (e *Engine) migrationTarget(ctx context.Context, provider, service, region string, req ClusterMigrationReq, current *ClusterRecommendationResp) (*ClusterRecommendationResp, error) {
+	fresh, err := e.RecommendCluster(ctx, provider, service, region, req.SingleClusterRecommendationReq, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := e.allowedNodePools(ctx, provider, req.SingleClusterRecommendationReq, current.NodePools)
+	if err != nil {
+		return nil, err
+	}
+	sizing := withHeadroom(req.SingleClusterRecommendationReq)
+	kept := keptNodePools(allowed, fresh.NodePools, sizing.SumCpu, sizing.SumMem)
+	if len(kept) == 0 {
+		e.log.Debug("none of the node pools are kept, migrating to the layout recommended from scratch")
+		return fresh, nil
+	}
+
+	workers := kept
+	if cpu, mem := workerResources(kept); cpu < sizing.SumCpu || mem < sizing.SumMem {
+		scaleOutReq := req.SingleClusterRecommendationReq
+		scaleOutReq.Includes = make([]string, 0, len(kept))
+		for _, np := range kept {
+			scaleOutReq.Includes = append(scaleOutReq.Includes, np.VmType.Type)
+		}
+		scaleOut, err := e.RecommendCluster(ctx, provider, service, region, scaleOutReq, nodePoolDescs(kept))
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			e.log.Debug("could not scale out the kept node pools, migrating to the layout recommended from scratch",
+				map[string]interface{}{"reason": err.Error()})
+			return fresh, nil
+		}
+		workers = scaleOut.NodePools
+	}
+
+	// the surge nodes are added on top of the maximum like in the layouts recommended from scratch
+	if nodes := workerNodes(workers); nodes < req.MinNodes || (req.MaxNodes > 0 && nodes > req.MaxNodes+req.SurgeNodes) {
+		e.log.Debug("the kept node pools don't fit the requested node count, migrating to the layout recommended from scratch",
+			map[string]interface{}{"nodes": nodes})
+		return fresh, nil
+	}
+
+	target, err := e.migrationLayout(ctx, provider, service, region, req.Zone, workers, current.NodePools)
+	if err != nil {
+		return nil, err
+	}
+	if fresh.Accuracy.RecTotalPrice < target.Accuracy.RecTotalPrice {
+		e.log.Debug("the layout recommended from scratch is cheaper than scaling out the kept node pools")
+		return fresh, nil
+	}
+	return target, nil
+}
+
+// allowedNodePools returns the worker node pools whose instance types pass the filters of the request; the regular and
+// the spot node pools are checked separately, the spot ones with the spot specific filters as well
+func (e *Engine) allowedNodePools(ctx context.Context, provider string, req SingleClusterRecommendationReq, nodePools []NodePool) ([]NodePool, error) {
+	allowed := make(map[string]bool)
+	for _, vmClass := range []string{Regular, Spot} {
+		var (
+			pools []NodePool
+			vms   []VirtualMachine
+		)
+		for _, np := range nodePools {
+			if np.Role != Master && np.VmClass == vmClass {
+				pools = append(pools, np)
+				vms = append(vms, np.VmType)
+			}
+		}
+		if len(pools) == 0 {
+			continue
+		}
+
+		classReq := req
+		classReq.OnDemandPct = 0
+		if vmClass == Regular {
+			classReq.OnDemandPct = 100
+		}
+		// the resource ratio filters of the attributes complement each other, so an instance type passes the filters
+		// of the request if it's selected for any of the attributes
+		for _, attr := range []string{Cpu, Memory} {
+			odVms, spotVms, err := e.vmSelector.RecommendVms(ctx, provider, vms, attr, classReq, pools)
+			if err != nil {
+				return nil, err
+			}
+			for _, vm := range append(odVms, spotVms...) {
+				allowed[vm.Type] = true
+			}
+		}
+		for _, np := range pools {
+			if !allowed[np.VmType.Type] {
+				e.log.Debug("the request doesn't allow the instance type of the node pool, it's not kept",
+					map[string]interface{}{"instanceType": np.VmType.Type, "vmClass": vmClass})
+			}
+		}
+	}
+
+	var nps []NodePool
+	for _, np := range nodePools {
+		if np.Role != Master && allowed[np.VmType.Type] {
+			nps = append(nps, np)
+		}
+	}
+	return nps, nil
+}
+
+// workerNodes returns the number of worker nodes of the node pools
+func workerNodes(nodePools []NodePool) int {
+	var nodes int
+	for _, np := range nodePools {
+		if np.Role != Master {
+			nodes += np.SumNodes
+		}
+	}
+	return nodes
+}
+
+// migrationLayout assembles the target layout of the worker node pools and the master node pools of the actual layout
+func (e *Engine) migrationLayout(ctx context.Context, provider, service, region, zone string, workers, current []NodePool) (*ClusterRecommendationResp, error) {
+	nodePools := make([]NodePool, 0, len(workers)+1)
+	for _, np := range workers {
+		if np.SumNodes > 0 {
+			nodePools = append(nodePools, np)
+		}
+	}
+	for _, np := range current {
+		if np.Role == Master {
+			nodePools = append(nodePools, np)
+		}
+	}
+
+	accuracy, err := e.withCostComponents(ctx, provider, service, region, zone, nodePools, findResponseSum(zone, nodePools))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClusterRecommendationResp{
+		Provider:  provider,
+		Service:   service,
+		Region:    region,
+		Zone:      zone,
+		NodePools: nodePools,
+		Accuracy:  accuracy,
+		Stale:     e.staleProducts(provider, service, region),
+	}, nil
+}
+
+// keptNodePools returns the worker node pools of the actual layout whose nodes are not more expensive per cpu and per
+// memory than the nodes of the same class recommended from scratch; the most expensive nodes are left out as long as
+// the kept node pools have the requested resources
+func keptNodePools(current, fresh []NodePool, minCpu, minMem float64) []NodePool {
+	var kept []NodePool
+	for _, np := range current {
+		if np.Role == Master || np.SumNodes == 0 || np.VmType.Cpus == 0 || np.VmType.Mem == 0 {
+			continue
+		}
+		cpuPrice, memPrice, ok := unitPrices(fresh, np.VmClass)
+		if !ok || nodePrice(np)/np.VmType.Cpus > cpuPrice || nodePrice(np)/np.VmType.Mem > memPrice {
+			continue
+		}
+		kept = append(kept, np)
+	}
+
+	// leave out the most expensive nodes first
+	sort.SliceStable(kept, func(i, j int) bool {
+		return nodePrice(kept[i])/kept[i].VmType.Cpus > nodePrice(kept[j])/kept[j].VmType.Cpus
+	})
+	cpu, mem := workerResources(kept)
+	nonEmpty := kept[:0]
+	for _, np := range kept {
+		for np.SumNodes > 0 && cpu-np.VmType.Cpus >= minCpu && mem-np.VmType.Mem >= minMem {
+			cpu -= np.VmType.Cpus
+			mem -= np.VmType.Mem
+			np.SumNodes--
+		}
+		if np.SumNodes > 0 {
+			nonEmpty = append(nonEmpty, np)
+		}
+	}
+	return nonEmpty
+}
+
+// unitPrices returns the price of a cpu and of a unit of memory in the worker node pools of the given class,
+// false if there are no such nodes
+func unitPrices(nodePools []NodePool, vmClass string) (float64, float64, bool) {
+	var price, cpu, mem float64
+	for _, np := range nodePools {
+		if np.Role != Master && np.VmClass == vmClass {
+			price += np.PoolPrice()
+			cpu += np.GetSum(Cpu)
+			mem += np.GetSum(Memory)
+		}
+	}
+	if cpu == 0 || mem == 0 {
+		return 0, 0, false
+	}
+	return price / cpu, price / mem, true
+}
+
+// nodePoolDescs describes the node pools
+func nodePoolDescs(nodePools []NodePool) []NodePoolDesc {
+	descs := make([]NodePoolDesc, 0, len(nodePools))
+	for _, np := range nodePools {
+		descs = append(descs, NodePoolDesc{InstanceType: np.VmType.Type, VmClass: np.VmClass, SumNodes: np.SumNodes})
+	}
+	return descs
+}
+
+// migrationPlanner transforms a layout to the target layout node pool by node pool
+// worker nodes are drained only as long as the worker pools keep the minimum resources
+type migrationPlanner struct {
+	pools       []NodePool
+	targetNodes []int
+	minCpu      float64
+	minMem      float64
+	// the cost components priced in every step like in the quotes of the current and the target layout
+	costs clusterCosts
+}
+
+func newMigrationPlanner(current, target []NodePool, minCpu, minMem float64, costs clusterCosts) *migrationPlanner {
+	mp := &migrationPlanner{
+		pools:       make([]NodePool, len(current)),
+		targetNodes: make([]int, len(current)),
+		minCpu:      minCpu,
+		minMem:      minMem,
+		costs:       costs,
+	}
+	copy(mp.pools, current)
+
+	for i, np := range mp.pools {
+		if np.Role == Master {
+			// master node pools are not part of the migration
+			mp.targetNodes[i] = np.SumNodes
+		}
+	}
+
+	for _, np := range target {
+		if np.Role == Master {
+			continue
+		}
+		i := mp.poolIndex(np)
+		if i < 0 {
+			mp.pools = append(mp.pools, NodePool{VmType: np.VmType, VmClass: np.VmClass, Role: np.Role})
+			mp.targetNodes = append(mp.targetNodes, 0)
+			i = len(mp.pools) - 1
+		}
+		mp.targetNodes[i] += np.SumNodes
+	}
+
+	return mp
+}
+
+// poolIndex returns the index of the worker pool with the same instance type and class, -1 if there's no such pool
+func (mp *migrationPlanner) poolIndex(np NodePool) int {
+	for i, p := range mp.pools {
+		if p.Role != Master && p.VmType.Type == np.VmType.Type && p.VmClass == np.VmClass {
+			return i
+		}
+	}
+	return -1
+}
+
+// plan assembles the migration steps; excess nodes are drained first, then every new node pool is added
+// followed by draining as many nodes from the expensive pools as the minimum resources allow
+func (mp *migrationPlanner) plan() []MigrationStep {
+	var adds, drains []int
+	for i, np := range mp.pools {
+		switch {
+		case mp.targetNodes[i] > np.SumNodes:
+			adds = append(adds, i)
+		case mp.targetNodes[i] < np.SumNodes:
+			drains = append(drains, i)
+		}
+	}
+
+	// drain the most expensive nodes first
+	sort.SliceStable(drains, func(i, j int) bool {
+		return nodePrice(mp.pools[drains[i]]) > nodePrice(mp.pools[drains[j]])
+	})
+
+	steps := mp.drain(drains)
+	for _, i := range adds {
+		nodes := mp.targetNodes[i] - mp.pools[i].SumNodes
+		mp.pools[i].SumNodes += nodes
+		steps = append(steps, mp.step(MigrationAdd, i, nodes))
+		steps = append(steps, mp.drain(drains)...)
+	}
+
+	return steps
+}
+
+// drain removes nodes from the given pools while the worker pools keep the minimum resources
+func (mp *migrationPlanner) drain(pools []int) []MigrationStep {
+	steps := make([]MigrationStep, 0)
+	for _, i := range pools {
+		cpu, mem := mp.workerResources()
+		vm := mp.pools[i].VmType
+
+		nodes := 0
+		for mp.pools[i].SumNodes-nodes > mp.targetNodes[i] &&
+			cpu-vm.Cpus >= mp.minCpu && mem-vm.Mem >= mp.minMem {
+			cpu -= vm.Cpus
+			mem -= vm.Mem
+			nodes++
+		}
+
+		if nodes > 0 {
+			mp.pools[i].SumNodes -= nodes
+			steps = append(steps, mp.step(MigrationDrain, i, nodes))
+		}
+	}
+	return steps
+}
+
+// step records the state of the cluster after changing the node pool at the given index
+func (mp *migrationPlanner) step(action string, i int, nodes int) MigrationStep {
+	cpu, mem := mp.workerResources()
+	totalPrice := mp.costs.add(mp.pools, findResponseSum("", mp.pools)).RecTotalPrice
+
+	return MigrationStep{
+		Action:       action,
+		InstanceType: mp.pools[i].VmType.Type,
+		VmClass:      mp.pools[i].VmClass,
+		Nodes:        nodes,
+		PoolNodes:    mp.pools[i].SumNodes,
+		Cpu:          cpu,
+		Mem:          mem,
+		TotalPrice:   totalPrice,
+	}
+}
+
+func (mp *migrationPlanner) workerResources() (float64, float64) {
+	return workerResources(mp.pools)
+}
+
+// complete checks whether all the node pools reached their target size
+func (mp *migrationPlanner) complete() bool {
+	for i, np := range mp.pools {
+		if np.SumNodes != mp.targetNodes[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// nodePrice returns the price of a single node in the pool
+func nodePrice(np NodePool) float64 {
+	np.SumNodes = 1
+	return np.PoolPrice()
+}
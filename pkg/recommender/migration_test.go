@@ -0,0 +1,317 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goph/logur"
+	"github.com/stretchr/testify/assert"
+)
+
+// layoutVms selects the instance types of the layout that are not excluded by the request, the vms of the
+// recommendations from scratch are the ones of dummyVms
+type layoutVms struct {
+	dummyVms
+}
+
+func (v *layoutVms) RecommendVms(ctx context.Context, provider string, vms []VirtualMachine, attr string, req SingleClusterRecommendationReq, layout []NodePool) ([]VirtualMachine, []VirtualMachine, error) {
+	if layout == nil {
+		return v.dummyVms.RecommendVms(ctx, provider, vms, attr, req, layout)
+	}
+	var odVms, spotVms []VirtualMachine
+	for _, np := range layout {
+		excluded := false
+		for _, t := range req.Excludes {
+			excluded = excluded || t == np.VmType.Type
+		}
+		switch {
+		case excluded:
+		case np.VmClass == Regular:
+			odVms = append(odVms, np.VmType)
+		default:
+			spotVms = append(spotVms, np.VmType)
+		}
+	}
+	return odVms, spotVms, nil
+}
+
+func TestEngine_RecommendClusterMigration(t *testing.T) {
+	tests := []struct {
+		name    string
+		request ClusterMigrationReq
+		check   func(resp *ClusterMigrationResp, err error)
+	}{
+		{
+			name: "migration keeps the requested resources",
+			request: ClusterMigrationReq{
+				SingleClusterRecommendationReq: SingleClusterRecommendationReq{
+					ClusterRecommendationReq: ClusterRecommendationReq{
+						MinNodes: 1,
+						MaxNodes: 1,
+						SumMem:   32,
+						SumCpu:   16,
+					},
+				},
+				ActualLayout: []NodePoolDesc{
+					{InstanceType: "dummy-type", VmClass: Regular, SumNodes: 2},
+				},
+			},
+			check: func(resp *ClusterMigrationResp, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.True(t, resp.Complete)
+				assert.Equal(t, []string{MigrationDrain, MigrationAdd, MigrationDrain},
+					[]string{resp.Steps[0].Action, resp.Steps[1].Action, resp.Steps[2].Action})
+				assert.Equal(t, float64(3), resp.Steps[0].TotalPrice)
+				assert.Equal(t, float64(5), resp.Steps[1].TotalPrice)
+				assert.Equal(t, float64(2), resp.Steps[2].TotalPrice)
+				for _, step := range resp.Steps {
+					assert.True(t, step.Cpu >= 16 && step.Mem >= 32, "resources dropped below the minimum")
+				}
+			},
+		},
+		{
+			name: "cheaper node pools of the actual layout are kept",
+			request: ClusterMigrationReq{
+				SingleClusterRecommendationReq: SingleClusterRecommendationReq{
+					ClusterRecommendationReq: ClusterRecommendationReq{
+						MinNodes: 1,
+						MaxNodes: 1,
+						SumMem:   32,
+						SumCpu:   16,
+					},
+				},
+				ActualLayout: []NodePoolDesc{
+					{InstanceType: "dummy-type", VmClass: Spot, SumNodes: 3},
+				},
+			},
+			check: func(resp *ClusterMigrationResp, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.True(t, resp.Complete)
+				assert.Equal(t, 1, len(resp.Target.NodePools))
+				assert.Equal(t, "dummy-type", resp.Target.NodePools[0].VmType.Type)
+				assert.Equal(t, 1, resp.Target.NodePools[0].SumNodes)
+				assert.Equal(t, float64(0.8), resp.Target.Accuracy.RecTotalPrice)
+				assert.Equal(t, 1, len(resp.Steps))
+				assert.Equal(t, MigrationDrain, resp.Steps[0].Action)
+				assert.Equal(t, 2, resp.Steps[0].Nodes)
+			},
+		},
+		{
+			name: "node pools of excluded instance types are not kept",
+			request: ClusterMigrationReq{
+				SingleClusterRecommendationReq: SingleClusterRecommendationReq{
+					ClusterRecommendationReq: ClusterRecommendationReq{
+						MinNodes: 1,
+						MaxNodes: 1,
+						SumMem:   32,
+						SumCpu:   16,
+					},
+					Excludes: []string{"dummy-type"},
+				},
+				ActualLayout: []NodePoolDesc{
+					{InstanceType: "dummy-type", VmClass: Spot, SumNodes: 3},
+				},
+			},
+			check: func(resp *ClusterMigrationResp, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, float64(2), resp.Target.Accuracy.RecTotalPrice)
+				for _, np := range resp.Target.NodePools {
+					assert.NotEqual(t, "dummy-type", np.VmType.Type)
+				}
+			},
+		},
+		{
+			name: "kept node pools exceeding the maximum node count are not kept",
+			request: ClusterMigrationReq{
+				SingleClusterRecommendationReq: SingleClusterRecommendationReq{
+					ClusterRecommendationReq: ClusterRecommendationReq{
+						MinNodes: 1,
+						MaxNodes: 1,
+						SumMem:   64,
+						SumCpu:   32,
+					},
+				},
+				ActualLayout: []NodePoolDesc{
+					{InstanceType: "dummy-type", VmClass: Spot, SumNodes: 2},
+				},
+			},
+			check: func(resp *ClusterMigrationResp, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				for _, np := range resp.Target.NodePools {
+					assert.NotEqual(t, "dummy-type", np.VmType.Type)
+				}
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			engine := NewEngine(logur.NewTestLogger(), &dummyProducts{}, &layoutVms{}, &dummyNodePools{})
+
+			test.check(engine.RecommendClusterMigration(context.Background(), "dummyProvider", "dummyService", "dummyRegion", test.request))
+		})
+	}
+}
+
+func TestEngine_RecommendClusterMigrationCosts(t *testing.T) {
+	components := dummyCostComponents{
+		{Name: "root volume", Unit: CostPerNode, Price: 0.01},
+		{Name: "nat gateway", Unit: CostPerZone, Price: 0.05},
+	}
+	engine := NewEngine(logur.NewTestLogger(), &dummyProducts{}, &dummyVms{}, &dummyNodePools{}, WithCostComponents(components))
+
+	resp, err := engine.RecommendClusterMigration(context.Background(), "dummyProvider", "dummyService", "dummyRegion", ClusterMigrationReq{
+		SingleClusterRecommendationReq: SingleClusterRecommendationReq{
+			ClusterRecommendationReq: ClusterRecommendationReq{MinNodes: 1, MaxNodes: 1, SumMem: 32, SumCpu: 16},
+		},
+		ActualLayout: []NodePoolDesc{{InstanceType: "dummy-type", VmClass: Regular, SumNodes: 2}},
+	})
+
+	assert.Nil(t, err, "the error should be nil")
+	assert.InDelta(t, 6+2*0.01+3*0.05, resp.Current.Accuracy.RecTotalPrice, 1e-9)
+	assert.InDelta(t, 3+0.01+3*0.05, resp.Steps[0].TotalPrice, 1e-9)
+	assert.InDelta(t, resp.Target.Accuracy.RecTotalPrice, resp.Steps[len(resp.Steps)-1].TotalPrice, 1e-9)
+}
+
+func TestKeptNodePools(t *testing.T) {
+	cheap := VirtualMachine{Type: "cheap", Cpus: 4, Mem: 16, OnDemandPrice: 0.2}
+	expensive := VirtualMachine{Type: "expensive", Cpus: 4, Mem: 16, OnDemandPrice: 0.6}
+	fresh := []NodePool{{VmType: VirtualMachine{Type: "fresh", Cpus: 8, Mem: 32, OnDemandPrice: 0.8}, SumNodes: 2, VmClass: Regular, Role: Worker}}
+
+	tests := []struct {
+		name    string
+		current []NodePool
+		minCpu  float64
+		minMem  float64
+		check   func(kept []NodePool)
+	}{
+		{
+			name: "pools more expensive than the fresh layout are not kept",
+			current: []NodePool{
+				{VmType: cheap, SumNodes: 2, VmClass: Regular, Role: Worker},
+				{VmType: expensive, SumNodes: 2, VmClass: Regular, Role: Worker},
+			},
+			minCpu: 16,
+			minMem: 64,
+			check: func(kept []NodePool) {
+				assert.Equal(t, 1, len(kept))
+				assert.Equal(t, "cheap", kept[0].VmType.Type)
+				assert.Equal(t, 2, kept[0].SumNodes)
+			},
+		},
+		{
+			name:    "nodes exceeding the requested resources are left out",
+			current: []NodePool{{VmType: cheap, SumNodes: 6, VmClass: Regular, Role: Worker}},
+			minCpu:  8,
+			minMem:  32,
+			check: func(kept []NodePool) {
+				assert.Equal(t, 1, len(kept))
+				assert.Equal(t, 2, kept[0].SumNodes)
+			},
+		},
+		{
+			name:    "pools of a class missing from the fresh layout are not kept",
+			current: []NodePool{{VmType: cheap, SumNodes: 2, VmClass: Spot, Role: Worker}},
+			minCpu:  8,
+			minMem:  32,
+			check: func(kept []NodePool) {
+				assert.Empty(t, kept)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			test.check(keptNodePools(test.current, fresh, test.minCpu, test.minMem))
+		})
+	}
+}
+
+func TestMigrationPlanner_plan(t *testing.T) {
+	large := VirtualMachine{Type: "large", Cpus: 8, Mem: 16, OnDemandPrice: 4}
+	small := VirtualMachine{Type: "small", Cpus: 2, Mem: 4, OnDemandPrice: 0.5}
+
+	tests := []struct {
+		name    string
+		current []NodePool
+		target  []NodePool
+		minCpu  float64
+		minMem  float64
+		costs   clusterCosts
+		check   func(steps []MigrationStep, complete bool)
+	}{
+		{
+			name:    "incomplete migration when the target is below the minimum",
+			current: []NodePool{{VmType: large, SumNodes: 2, VmClass: Regular, Role: Worker}},
+			target:  []NodePool{{VmType: small, SumNodes: 4, VmClass: Regular, Role: Worker}},
+			minCpu:  12,
+			minMem:  24,
+			check: func(steps []MigrationStep, complete bool) {
+				assert.False(t, complete)
+				assert.Equal(t, 2, len(steps))
+				assert.Equal(t, MigrationAdd, steps[0].Action)
+				assert.Equal(t, MigrationDrain, steps[1].Action)
+				assert.Equal(t, 1, steps[1].PoolNodes)
+				assert.Equal(t, float64(16), steps[1].Cpu)
+			},
+		},
+		{
+			name: "master pools are left untouched",
+			current: []NodePool{
+				{VmType: large, SumNodes: 1, VmClass: Regular, Role: Worker},
+				{VmType: small, SumNodes: 1, VmClass: Regular, Role: Master},
+			},
+			target: []NodePool{
+				{VmType: small, SumNodes: 4, VmClass: Regular, Role: Worker},
+			},
+			minCpu: 8,
+			minMem: 16,
+			check: func(steps []MigrationStep, complete bool) {
+				assert.True(t, complete)
+				assert.Equal(t, 2, len(steps))
+				assert.Equal(t, float64(2.5), steps[1].TotalPrice)
+			},
+		},
+		{
+			name:    "steps are priced with the cost components",
+			current: []NodePool{{VmType: large, SumNodes: 1, VmClass: Regular, Role: Worker}},
+			target:  []NodePool{{VmType: small, SumNodes: 4, VmClass: Regular, Role: Worker}},
+			minCpu:  8,
+			minMem:  16,
+			costs: clusterCosts{
+				service: "compute",
+				components: []CostComponent{
+					{Name: "root volume", Unit: CostPerNode, Price: 0.01},
+					{Name: "load balancer", Unit: CostPerCluster, Price: 0.025},
+				},
+			},
+			check: func(steps []MigrationStep, complete bool) {
+				assert.True(t, complete)
+				assert.Equal(t, 2, len(steps))
+				assert.InDelta(t, 6+5*0.01+0.025, steps[0].TotalPrice, 1e-9)
+				assert.InDelta(t, 2+4*0.01+0.025, steps[1].TotalPrice, 1e-9)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			planner := newMigrationPlanner(test.current, test.target, test.minCpu, test.minMem, test.costs)
+			test.check(planner.plan(), planner.complete())
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mockcloudinfo
+
+import (
+	"github.com/banzaicloud/telescopes/.gen/cloudinfo"
+	"github.com/banzaicloud/telescopes/pkg/recommender"
+	"github.com/goph/emperror"
+)
+
+// Catalog holds the cloud info data served by the mock server
+type Catalog struct {
+	// Supported continents
+	Continents []string
+	Providers  []Provider
+}
+
+// Provider is a cloud provider of the catalog
+type Provider struct {
+	Name     string
+	Services []Service
+}
+
+// Service is a service of a cloud provider
+type Service struct {
+	Name     string
+	IsStatic bool
+	Regions  []Region
+}
+
+// Region holds the zones, products, images and Kubernetes versions of a region
+type Region struct {
+	Id        string
+	Name      string
+	Continent string
+	Zones     []string
+	Products  []cloudinfo.ProductDetails
+	// Images of the region, the images call responds not found if nil
+	Images []cloudinfo.Image
+	// Kubernetes versions of the region, the versions call responds not found if nil
+	Versions []cloudinfo.LocationVersion
+}
+
+func (c *Catalog) provider(name string) *Provider {
+	for i := range c.Providers {
+		if c.Providers[i].Name == name {
+			return &c.Providers[i]
+		}
+	}
+	return nil
+}
+
+func (p *Provider) service(name string) *Service {
+	for i := range p.Services {
+		if p.Services[i].Name == name {
+			return &p.Services[i]
+		}
+	}
+	return nil
+}
+
+func (s *Service) region(id string) *Region {
+	for i := range s.Regions {
+		if s.Regions[i].Id == id {
+			return &s.Regions[i]
+		}
+	}
+	return nil
+}
+
+// continents returns the regions of the service grouped by continents
+func (s *Service) continents() []cloudinfo.Continent {
+	var continents []cloudinfo.Continent
+	for _, r := range s.Regions {
+		region := cloudinfo.Region{Id: r.Id, Name: r.Name}
+		found := false
+		for i := range continents {
+			if continents[i].Name == r.Continent {
+				continents[i].Regions = append(continents[i].Regions, region)
+				found = true
+			}
+		}
+		if !found {
+			continents = append(continents, cloudinfo.Continent{Name: r.Continent, Regions: []cloudinfo.Region{region}})
+		}
+	}
+	return continents
+}
+
+// LoadSnapshot loads the catalog from a cloud info snapshot directory; the images and versions of the regions are optional
+func LoadSnapshot(dir string) (*Catalog, error) {
+	catalog := &Catalog{}
+	if err := recommender.ReadSnapshotFile(dir, &catalog.Continents, recommender.SnapshotContinentsFile); err != nil {
+		return nil, err
+	}
+
+	var providers cloudinfo.ProvidersResponse
+	if err := recommender.ReadSnapshotFile(dir, &providers, recommender.SnapshotProvidersFile); err != nil {
+		return nil, err
+	}
+	for _, p := range providers.Providers {
+		provider := Provider{Name: p.Provider}
+		for _, s := range p.Services {
+			service, err := loadService(dir, p.Provider, s)
+			if err != nil {
+				return nil, emperror.With(err, "provider", p.Provider, "service", s.Service)
+			}
+			provider.Services = append(provider.Services, *service)
+		}
+		catalog.Providers = append(catalog.Providers, provider)
+	}
+	return catalog, nil
+}
+
+func loadService(dir, provider string, s cloudinfo.Service) (*Service, error) {
+	var regions []cloudinfo.Region
+	if err := recommender.ReadSnapshotFile(dir, &regions, provider, s.Service, recommender.SnapshotRegionsFile); err != nil {
+		return nil, err
+	}
+	var continents []cloudinfo.Continent
+	if err := recommender.ReadSnapshotFile(dir, &continents, provider, s.Service, recommender.SnapshotContinentsDataFile); err != nil {
+		return nil, err
+	}
+	continentOf := make(map[string]string)
+	for _, c := range continents {
+		for _, r := range c.Regions {
+			continentOf[r.Id] = c.Name
+		}
+	}
+
+	service := &Service{Name: s.Service, IsStatic: s.IsStatic}
+	for _, r := range regions {
+		region := Region{Id: r.Id, Name: r.Name, Continent: continentOf[r.Id]}
+		if err := loadRegion(dir, provider, s.Service, &region); err != nil {
+			return nil, emperror.With(err, "region", r.Id)
+		}
+		service.Regions = append(service.Regions, region)
+	}
+	return service, nil
+}
+
+func loadRegion(dir, provider, service string, region *Region) error {
+	var r cloudinfo.GetRegionResp
+	if err := recommender.ReadSnapshotFile(dir, &r, provider, service, region.Id, recommender.SnapshotRegionFile); err != nil {
+		return err
+	}
+	region.Zones = r.Zones
+
+	var products cloudinfo.ProductDetailsResponse
+	if err := recommender.ReadSnapshotFile(dir, &products, provider, service, region.Id, recommender.SnapshotProductsFile); err != nil {
+		return err
+	}
+	region.Products = products.Products
+
+	var images cloudinfo.ImagesResponse
+	switch err := recommender.ReadSnapshotFile(dir, &images, provider, service, region.Id, recommender.SnapshotImagesFile); {
+	case err == nil:
+		region.Images = append([]cloudinfo.Image{}, images.Images...)
+	case !recommender.NotFound(err):
+		return err
+	}
+
+	var versions cloudinfo.VersionsResponse
+	switch err := recommender.ReadSnapshotFile(dir, &versions, provider, service, region.Id, recommender.SnapshotVersionsFile); {
+	case err == nil:
+		region.Versions = append([]cloudinfo.LocationVersion{}, versions.Versions...)
+	case !recommender.NotFound(err):
+		return err
+	}
+	return nil
+}
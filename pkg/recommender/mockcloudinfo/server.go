@@ -0,0 +1,206 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mockcloudinfo
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/banzaicloud/telescopes/.gen/cloudinfo"
+	"github.com/gin-gonic/gin"
+	"github.com/goph/logur"
+)
+
+// BasePath is the base path of the cloud info API served by the mock server
+const BasePath = "/api/v1"
+
+// server serves the cloud info API paths of .gen/cloudinfo/api/openapi.yaml from a catalog
+type server struct {
+	catalog *Catalog
+	log     logur.Logger
+}
+
+// NewHandler creates the handler serving the cloud info API from the catalog under the base path
+func NewHandler(catalog *Catalog, log logur.Logger) http.Handler {
+	s := &server{
+		catalog: catalog,
+		log:     logur.WithFields(log, map[string]interface{}{"component": "mock-cloud-info"}),
+	}
+
+	router := gin.New()
+	router.Use(gin.Recovery(), s.logRequests)
+
+	v1 := router.Group(BasePath)
+	{
+		v1.GET("/continents", s.getContinents)
+		v1.GET("/providers", s.getProviders)
+		v1.GET("/providers/:provider", s.getProvider)
+		v1.GET("/providers/:provider/services", s.getServices)
+		v1.GET("/providers/:provider/services/:service", s.getService)
+		v1.GET("/providers/:provider/services/:service/continents", s.getContinentsData)
+		v1.GET("/providers/:provider/services/:service/regions", s.getRegions)
+		v1.GET("/providers/:provider/services/:service/regions/:region", s.getRegion)
+		v1.GET("/providers/:provider/services/:service/regions/:region/images", s.getImages)
+		v1.GET("/providers/:provider/services/:service/regions/:region/products", s.getProducts)
+		v1.GET("/providers/:provider/services/:service/regions/:region/versions", s.getVersions)
+	}
+	return router
+}
+
+func (s *server) logRequests(c *gin.Context) {
+	c.Next()
+	s.log.Debug("served cloud info request", map[string]interface{}{"path": c.Request.URL.Path, "status": c.Writer.Status()})
+}
+
+func (s *server) getContinents(c *gin.Context) {
+	c.JSON(http.StatusOK, s.catalog.Continents)
+}
+
+func (s *server) getProviders(c *gin.Context) {
+	var resp cloudinfo.ProvidersResponse
+	for _, p := range s.catalog.Providers {
+		resp.Providers = append(resp.Providers, cloudinfo.Provider{Provider: p.Name, Services: services(p)})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (s *server) getProvider(c *gin.Context) {
+	if p := s.provider(c); p != nil {
+		c.JSON(http.StatusOK, cloudinfo.ProviderResponse{Provider: cloudinfo.Provider{Provider: p.Name, Services: services(*p)}})
+	}
+}
+
+func (s *server) getServices(c *gin.Context) {
+	if p := s.provider(c); p != nil {
+		c.JSON(http.StatusOK, cloudinfo.ServicesResponse{Services: services(*p)})
+	}
+}
+
+func (s *server) getService(c *gin.Context) {
+	if svc := s.service(c); svc != nil {
+		c.JSON(http.StatusOK, cloudinfo.ServiceResponse{Service: cloudinfo.Service{Service: svc.Name, IsStatic: svc.IsStatic}})
+	}
+}
+
+func (s *server) getContinentsData(c *gin.Context) {
+	if svc := s.service(c); svc != nil {
+		c.JSON(http.StatusOK, svc.continents())
+	}
+}
+
+func (s *server) getRegions(c *gin.Context) {
+	if svc := s.service(c); svc != nil {
+		regions := make([]cloudinfo.Region, 0, len(svc.Regions))
+		for _, r := range svc.Regions {
+			regions = append(regions, cloudinfo.Region{Id: r.Id, Name: r.Name})
+		}
+		c.JSON(http.StatusOK, regions)
+	}
+}
+
+func (s *server) getRegion(c *gin.Context) {
+	if r := s.region(c); r != nil {
+		c.JSON(http.StatusOK, cloudinfo.GetRegionResp{Id: r.Id, Name: r.Name, Zones: r.Zones})
+	}
+}
+
+// getImages responds the images of the region, filtered by the optional gpu and version query parameters
+func (s *server) getImages(c *gin.Context) {
+	r := s.region(c)
+	if r == nil {
+		return
+	}
+	if r.Images == nil {
+		s.notFound(c, fmt.Sprintf("images of region %s", r.Id))
+		return
+	}
+
+	gpu, gpuErr := strconv.ParseBool(c.Query("gpu"))
+	version := c.Query("version")
+	images := make([]cloudinfo.Image, 0, len(r.Images))
+	for _, image := range r.Images {
+		if (gpuErr == nil && image.Gpu != gpu) || (version != "" && image.Version != version) {
+			continue
+		}
+		images = append(images, image)
+	}
+	c.JSON(http.StatusOK, cloudinfo.ImagesResponse{Images: images})
+}
+
+func (s *server) getProducts(c *gin.Context) {
+	if r := s.region(c); r != nil {
+		c.JSON(http.StatusOK, cloudinfo.ProductDetailsResponse{Products: r.Products})
+	}
+}
+
+func (s *server) getVersions(c *gin.Context) {
+	r := s.region(c)
+	if r == nil {
+		return
+	}
+	if r.Versions == nil {
+		s.notFound(c, fmt.Sprintf("versions of region %s", r.Id))
+		return
+	}
+	c.JSON(http.StatusOK, cloudinfo.VersionsResponse{Versions: r.Versions})
+}
+
+// provider looks up the provider of the request, responds not found if it's missing from the catalog
+func (s *server) provider(c *gin.Context) *Provider {
+	p := s.catalog.provider(c.Param("provider"))
+	if p == nil {
+		s.notFound(c, fmt.Sprintf("provider %s", c.Param("provider")))
+	}
+	return p
+}
+
+// service looks up the service of the request, responds not found if it's missing from the catalog
+func (s *server) service(c *gin.Context) *Service {
+	p := s.provider(c)
+	if p == nil {
+		return nil
+	}
+	svc := p.service(c.Param("service"))
+	if svc == nil {
+		s.notFound(c, fmt.Sprintf("service %s of provider %s", c.Param("service"), p.Name))
+	}
+	return svc
+}
+
+// region looks up the region of the request, responds not found if it's missing from the catalog
+func (s *server) region(c *gin.Context) *Region {
+	svc := s.service(c)
+	if svc == nil {
+		return nil
+	}
+	r := svc.region(c.Param("region"))
+	if r == nil {
+		s.notFound(c, fmt.Sprintf("region %s of service %s", c.Param("region"), svc.Name))
+	}
+	return r
+}
+
+func (s *server) notFound(c *gin.Context, what string) {
+	c.JSON(http.StatusNotFound, gin.H{"code": http.StatusNotFound, "message": fmt.Sprintf("%s not found", what)})
+}
+
+func services(p Provider) []cloudinfo.Service {
+	services := make([]cloudinfo.Service, 0, len(p.Services))
+	for _, s := range p.Services {
+		services = append(services, cloudinfo.Service{Service: s.Name, IsStatic: s.IsStatic})
+	}
+	return services
+}
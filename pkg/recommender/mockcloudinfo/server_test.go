@@ -0,0 +1,155 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mockcloudinfo
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/banzaicloud/telescopes/.gen/cloudinfo"
+	"github.com/banzaicloud/telescopes/pkg/recommender"
+	"github.com/banzaicloud/telescopes/pkg/recommender/snapshot"
+	"github.com/go-openapi/runtime"
+	"github.com/goph/logur"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	srv := httptest.NewServer(NewHandler(NewSyntheticCatalog(), logur.NewTestLogger()))
+	defer srv.Close()
+
+	ciCli := recommender.NewCloudInfoClient(srv.URL+BasePath, 0, logur.NewTestLogger())
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		check func()
+	}{
+		{
+			name: "providers, services and regions",
+			check: func() {
+				provider, err := ciCli.GetProvider(ctx, "google")
+				assert.NoError(t, err)
+				assert.Equal(t, "google", provider)
+
+				service, err := ciCli.GetService(ctx, "google", "gke")
+				assert.NoError(t, err)
+				assert.Equal(t, "gke", service)
+
+				regions, err := ciCli.GetRegions(ctx, "amazon", "eks")
+				assert.NoError(t, err)
+				assert.Equal(t, []cloudinfo.Region{{Id: "eu-west-1", Name: "EU (Ireland)"}, {Id: "us-east-1", Name: "US East (N. Virginia)"}}, regions)
+
+				zones, err := ciCli.GetZones(ctx, "azure", "aks", "westeurope")
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"1", "2", "3"}, zones)
+
+				continents, err := ciCli.GetContinents(ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"Europe", "North America"}, continents)
+			},
+		},
+		{
+			name: "products",
+			check: func() {
+				vms, err := ciCli.GetProductDetails(ctx, "amazon", "compute", "eu-west-1")
+				assert.NoError(t, err)
+				assert.Equal(t, 13, len(vms))
+				// the average of the spot prices of the zones
+				assert.InDelta(t, 0.0302, vms[0].AvgPrice, 0.0001)
+				vms[0].AvgPrice = 0
+				assert.Equal(t, recommender.VirtualMachine{
+					Type:                "m5.large",
+					Category:            "General purpose",
+					Cpus:                2,
+					Mem:                 8,
+					OnDemandPrice:       0.096,
+					NetworkPerf:         "Up to 10 Gigabit",
+					NetworkPerfCat:      "medium",
+					NetworkGbps:         10,
+					CurrentGen:          true,
+					Zones:               []string{"eu-west-1a", "eu-west-1b", "eu-west-1c"},
+					WindowsLicensePrice: 0.092,
+				}, vms[0])
+			},
+		},
+		{
+			name: "images and versions",
+			check: func() {
+				images, err := ciCli.GetImages(ctx, "amazon", "eks", "eu-west-1")
+				assert.NoError(t, err)
+				assert.Equal(t, 6, len(images))
+
+				versions, err := ciCli.GetVersions(ctx, "amazon", "eks", "eu-west-1")
+				assert.NoError(t, err)
+				assert.Equal(t, "1.15.5", versions[0].Default)
+
+				_, err = ciCli.GetVersions(ctx, "amazon", "compute", "eu-west-1")
+				assert.Equal(t, http.StatusNotFound, errors.Cause(err).(*runtime.APIError).Code)
+			},
+		},
+		{
+			name: "filtered images",
+			check: func() {
+				resp, err := http.Get(srv.URL + BasePath + "/providers/google/services/gke/regions/us-central1/images?gpu=true&version=1.14.8")
+				assert.NoError(t, err)
+				defer resp.Body.Close()
+
+				var images cloudinfo.ImagesResponse
+				assert.NoError(t, json.NewDecoder(resp.Body).Decode(&images))
+				assert.Equal(t, []cloudinfo.Image{{Name: "gke-gpu-node-1.14.8", Version: "1.14.8", Gpu: true}}, images.Images)
+			},
+		},
+		{
+			name: "unknown provider and region",
+			check: func() {
+				_, err := ciCli.GetProvider(ctx, "alibaba")
+				assert.Equal(t, http.StatusNotFound, errors.Cause(err).(*runtime.APIError).Code)
+
+				_, err = ciCli.GetRegion(ctx, "amazon", "compute", "eu-north-9")
+				assert.Equal(t, http.StatusNotFound, errors.Cause(err).(*runtime.APIError).Code)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test // scopelint
+		t.Run(test.name, func(t *testing.T) {
+			test.check()
+		})
+	}
+}
+
+func TestLoadSnapshot(t *testing.T) {
+	catalog := NewSyntheticCatalog()
+	srv := httptest.NewServer(NewHandler(catalog, logur.NewTestLogger()))
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = snapshot.NewExporter(srv.URL+BasePath, "test", logur.NewTestLogger()).Export(dir, snapshot.Filter{})
+	assert.NoError(t, err)
+
+	loaded, err := LoadSnapshot(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, catalog, loaded)
+}
@@ -0,0 +1,264 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mockcloudinfo
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/banzaicloud/telescopes/.gen/cloudinfo"
+)
+
+// Kubernetes versions offered by the managed Kubernetes services of the synthetic catalog, the last one is the default
+// nolint: gochecknoglobals
+var syntheticVersions = []string{"1.13.12", "1.14.8", "1.15.5"}
+
+// family is a synthetic instance type family, sized by the number of vCPUs
+type family struct {
+	category    string
+	memPerCpu   float64
+	pricePerCpu float64
+	name        func(cpus int) string
+}
+
+// machine is a synthetic instance type of fixed size, eg. a GPU instance type
+type machine struct {
+	name     string
+	category string
+	cpus     float64
+	mem      float64
+	gpus     float64
+	price    float64
+}
+
+type syntheticRegion struct {
+	id        string
+	name      string
+	continent string
+	zones     []string
+}
+
+// syntheticProvider describes the catalog generated for a provider
+type syntheticProvider struct {
+	name string
+	// compute service and managed Kubernetes service of the provider
+	compute    string
+	kubernetes string
+	// price multiplier of the provider
+	priceFactor float64
+	regions     []syntheticRegion
+	families    []family
+	machines    []machine
+}
+
+// nolint: gochecknoglobals
+var syntheticProviders = []syntheticProvider{
+	{
+		name:        "amazon",
+		compute:     "compute",
+		kubernetes:  "eks",
+		priceFactor: 1,
+		regions: []syntheticRegion{
+			{id: "eu-west-1", name: "EU (Ireland)", continent: "Europe", zones: []string{"eu-west-1a", "eu-west-1b", "eu-west-1c"}},
+			{id: "us-east-1", name: "US East (N. Virginia)", continent: "North America", zones: []string{"us-east-1a", "us-east-1b", "us-east-1c"}},
+		},
+		families: []family{
+			{category: "General purpose", memPerCpu: 4, pricePerCpu: 0.048, name: amazonName("m5")},
+			{category: "Compute optimized", memPerCpu: 2, pricePerCpu: 0.0425, name: amazonName("c5")},
+			{category: "Memory optimized", memPerCpu: 8, pricePerCpu: 0.063, name: amazonName("r5")},
+		},
+		machines: []machine{
+			{name: "p3.2xlarge", category: "GPU instance", cpus: 8, mem: 61, gpus: 1, price: 3.06},
+		},
+	},
+	{
+		name:        "google",
+		compute:     "compute",
+		kubernetes:  "gke",
+		priceFactor: 0.95,
+		regions: []syntheticRegion{
+			{id: "europe-west1", name: "Belgium", continent: "Europe", zones: []string{"europe-west1-b", "europe-west1-c", "europe-west1-d"}},
+			{id: "us-central1", name: "Iowa", continent: "North America", zones: []string{"us-central1-a", "us-central1-b", "us-central1-c"}},
+		},
+		families: []family{
+			{category: "General purpose", memPerCpu: 3.75, pricePerCpu: 0.0475, name: googleName("standard")},
+			{category: "Compute optimized", memPerCpu: 0.9, pricePerCpu: 0.0354, name: googleName("highcpu")},
+			{category: "Memory optimized", memPerCpu: 6.5, pricePerCpu: 0.0592, name: googleName("highmem")},
+		},
+		machines: []machine{
+			{name: "a2-highgpu-1g", category: "GPU instance", cpus: 12, mem: 85, gpus: 1, price: 3.67},
+		},
+	},
+	{
+		name:        "azure",
+		compute:     "compute",
+		kubernetes:  "aks",
+		priceFactor: 1.05,
+		regions: []syntheticRegion{
+			{id: "westeurope", name: "West Europe", continent: "Europe", zones: []string{"1", "2", "3"}},
+			{id: "eastus", name: "East US", continent: "North America", zones: []string{"1", "2", "3"}},
+		},
+		families: []family{
+			{category: "General purpose", memPerCpu: 4, pricePerCpu: 0.048, name: azureName("D", "s_v3")},
+			{category: "Compute optimized", memPerCpu: 2, pricePerCpu: 0.0423, name: azureName("F", "s_v2")},
+			{category: "Memory optimized", memPerCpu: 8, pricePerCpu: 0.063, name: azureName("E", "s_v3")},
+		},
+		machines: []machine{
+			{name: "Standard_NC6", category: "GPU instance", cpus: 6, mem: 56, gpus: 1, price: 0.9},
+		},
+	},
+}
+
+// sizes of the instance types of the families in vCPUs
+// nolint: gochecknoglobals
+var syntheticSizes = []int{2, 4, 8, 16}
+
+func amazonName(family string) func(cpus int) string {
+	return func(cpus int) string {
+		switch cpus {
+		case 2:
+			return family + ".large"
+		case 4:
+			return family + ".xlarge"
+		default:
+			return fmt.Sprintf("%s.%dxlarge", family, cpus/4)
+		}
+	}
+}
+
+func googleName(family string) func(cpus int) string {
+	return func(cpus int) string {
+		return fmt.Sprintf("n1-%s-%d", family, cpus)
+	}
+}
+
+func azureName(series, suffix string) func(cpus int) string {
+	return func(cpus int) string {
+		return fmt.Sprintf("Standard_%s%d%s", series, cpus, suffix)
+	}
+}
+
+// NewSyntheticCatalog generates a deterministic catalog of the amazon, google and azure providers with their compute
+// and managed Kubernetes services; the prices vary by region and the spot prices by zone, the managed Kubernetes
+// services have images and versions
+func NewSyntheticCatalog() *Catalog {
+	catalog := &Catalog{}
+	for _, sp := range syntheticProviders {
+		provider := Provider{Name: sp.name}
+		for _, service := range []string{sp.compute, sp.kubernetes} {
+			svc := Service{Name: service}
+			for i, sr := range sp.regions {
+				catalog.Continents = appendMissing(catalog.Continents, sr.continent)
+
+				region := Region{
+					Id:        sr.id,
+					Name:      sr.name,
+					Continent: sr.continent,
+					Zones:     sr.zones,
+					Products:  sp.products(sr, 1+0.1*float64(i)),
+				}
+				if service == sp.kubernetes {
+					region.Images = syntheticImages(service)
+					region.Versions = []cloudinfo.LocationVersion{{
+						Location: sr.id,
+						Default:  syntheticVersions[len(syntheticVersions)-1],
+						Versions: syntheticVersions,
+					}}
+				}
+				svc.Regions = append(svc.Regions, region)
+			}
+			provider.Services = append(provider.Services, svc)
+		}
+		catalog.Providers = append(catalog.Providers, provider)
+	}
+	return catalog
+}
+
+// products generates the instance types of the provider in the region
+func (sp syntheticProvider) products(region syntheticRegion, regionFactor float64) []cloudinfo.ProductDetails {
+	var products []cloudinfo.ProductDetails
+	for _, f := range sp.families {
+		for _, cpus := range syntheticSizes {
+			products = append(products, sp.product(region, machine{
+				name:     f.name(cpus),
+				category: f.category,
+				cpus:     float64(cpus),
+				mem:      f.memPerCpu * float64(cpus),
+				price:    f.pricePerCpu * float64(cpus),
+			}, regionFactor))
+		}
+	}
+	for _, m := range sp.machines {
+		products = append(products, sp.product(region, m, regionFactor))
+	}
+	return products
+}
+
+func (sp syntheticProvider) product(region syntheticRegion, m machine, regionFactor float64) cloudinfo.ProductDetails {
+	onDemandPrice := round(m.price * sp.priceFactor * regionFactor)
+
+	spotPrices := make([]cloudinfo.ZonePrice, 0, len(region.zones))
+	for i, zone := range region.zones {
+		spotPrices = append(spotPrices, cloudinfo.ZonePrice{Zone: zone, Price: round(onDemandPrice * 0.3 * (1 + 0.05*float64(i)))})
+	}
+
+	ntwPerf, ntwPerfCategory := "Up to 10 Gigabit", "medium"
+	if m.cpus >= 16 || m.gpus > 0 {
+		ntwPerf, ntwPerfCategory = "10 Gigabit", "high"
+	}
+
+	return cloudinfo.ProductDetails{
+		Type:            m.name,
+		Category:        m.category,
+		CpusPerVm:       m.cpus,
+		MemPerVm:        m.mem,
+		GpusPerVm:       m.gpus,
+		OnDemandPrice:   onDemandPrice,
+		SpotPrice:       spotPrices,
+		NtwPerf:         ntwPerf,
+		NtwPerfCategory: ntwPerfCategory,
+		CurrentGen:      true,
+		Zones:           region.zones,
+		Attributes: map[string]string{
+			"windowsOnDemandPrice": strconv.FormatFloat(round(onDemandPrice+0.046*m.cpus), 'f', -1, 64),
+		},
+	}
+}
+
+// syntheticImages generates a regular and a GPU node image of every version
+func syntheticImages(service string) []cloudinfo.Image {
+	var images []cloudinfo.Image
+	for _, version := range syntheticVersions {
+		images = append(images,
+			cloudinfo.Image{Name: fmt.Sprintf("%s-node-%s", service, version), Version: version},
+			cloudinfo.Image{Name: fmt.Sprintf("%s-gpu-node-%s", service, version), Version: version, Gpu: true},
+		)
+	}
+	return images
+}
+
+func round(price float64) float64 {
+	return math.Round(price*10000) / 10000
+}
+
+func appendMissing(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
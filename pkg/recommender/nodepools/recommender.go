@@ -15,12 +15,15 @@
 package nodepools
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"sort"
 
 	"github.com/banzaicloud/telescopes/pkg/recommender"
+	"github.com/goph/emperror"
 	"github.com/goph/logur"
+	"github.com/pkg/errors"
 )
 
 type nodePoolSelector struct {
@@ -34,11 +37,14 @@ func NewNodePoolSelector(log logur.Logger) *nodePoolSelector {
 }
 
 // RecommendNodePools finds the slice of NodePools that may participate in the recommendation process
-func (s *nodePoolSelector) RecommendNodePools(attr string, req recommender.SingleClusterRecommendationReq,
+func (s *nodePoolSelector) RecommendNodePools(ctx context.Context, attr string, req recommender.SingleClusterRecommendationReq,
 	layout []recommender.NodePool,
 	odVms []recommender.VirtualMachine,
 	spotVms []recommender.VirtualMachine,
-) []recommender.NodePool {
+) ([]recommender.NodePool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	s.log.Debug(fmt.Sprintf("requested sum for attribute [%s]: [%f]", attr, sum(req, attr)))
 	sumOnDemandValue := sum(req, attr) * float64(req.OnDemandPct) / 100
 	s.log.Debug(fmt.Sprintf("on demand sum value for attr [%s]: [%f]", attr, sumOnDemandValue))
@@ -91,17 +97,27 @@ func (s *nodePoolSelector) RecommendNodePools(attr string, req recommender.Singl
 		excludedSpotNps := make([]recommender.NodePool, 0)
 
 		s.sortByAttrValue(attr, spotVms)
+		if req.RankByInterruptionRisk {
+			sort.Stable(ByInterruptionRisk(spotVms))
+		}
 
 		var N int
 		if layout == nil {
 			// the "magic" number of machines for diversifying the types
 			N = int(math.Min(float64(findN(avgSpotNodeCount(req.MinNodes, req.MaxNodes, odNodesToAdd))), float64(len(spotVms))))
+			if N == 0 && len(spotVms) > 0 {
+				// the on-demand nodes may exceed the average node count, the remaining spot capacity still needs a pool
+				N = 1
+			}
 			// the second "magic" number for diversifying the layout
 			M := findM(N, spotVms)
 			s.log.Debug(fmt.Sprintf("Magic 'Marton' numbers: N=%d, M=%d", N, M))
 
 			// the first M vm-s
 			recommendedVms := spotVms[:M]
+			if diversified(req) {
+				recommendedVms = spreadAcrossFamilies(spotVms)[:M]
+			}
 
 			// create spot nodepools - one for the first M vm-s
 			for _, vm := range recommendedVms {
@@ -136,7 +152,14 @@ func (s *nodePoolSelector) RecommendNodePools(attr string, req recommender.Singl
 			N = findNWithLayout(nonZeroNPs, len(spotVms))
 			s.log.Debug(fmt.Sprintf("Magic 'Marton' number: N=%d", N))
 		}
-		spotNps = s.fillSpotNodePools(sumSpotValue, N, spotNps, attr)
+		if N == 0 && sumSpotValue > 0 {
+			return nil, emperror.With(errors.New("no spot node pool to cover the spot capacity"), recommender.RecommenderErrorTag)
+		}
+		if diversified(req) {
+			spotNps = s.fillDiversifiedSpotNodePools(sumSpotValue, N, spotNps, attr, req)
+		} else {
+			spotNps = s.fillSpotNodePools(sumSpotValue, N, spotNps, attr)
+		}
 		if len(excludedSpotNps) > 0 {
 			spotNps = append(spotNps, excludedSpotNps...)
 		}
@@ -144,7 +167,7 @@ func (s *nodePoolSelector) RecommendNodePools(attr string, req recommender.Singl
 
 	s.log.Debug(fmt.Sprintf("created [%d] regular and [%d] spot price node pools", len(odNps), len(spotNps)))
 
-	return append(odNps, spotNps...)
+	return append(odNps, spotNps...), nil
 }
 
 // sortByAttrValue returns the slice for
@@ -182,6 +205,21 @@ func (a ByAvgPricePerMemory) Less(i, j int) bool {
 	return pricePerMem1 < pricePerMem2
 }
 
+// ByInterruptionRisk type for custom sorting of a slice of vms, vms with unknown interruption frequency come last
+type ByInterruptionRisk []recommender.VirtualMachine
+
+func (a ByInterruptionRisk) Len() int      { return len(a) }
+func (a ByInterruptionRisk) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a ByInterruptionRisk) Less(i, j int) bool {
+	if a[i].InterruptionRisk == nil {
+		return false
+	}
+	if a[j].InterruptionRisk == nil {
+		return true
+	}
+	return *a[i].InterruptionRisk < *a[j].InterruptionRisk
+}
+
 type ByNonZeroNodePools []recommender.NodePool
 
 func (a ByNonZeroNodePools) Len() int      { return len(a) }
@@ -293,3 +331,92 @@ func avgSpotNodeCount(minNodes, maxNodes, odNodes int) int {
 func getNextSum(n recommender.NodePool, attr string) float64 {
 	return n.GetSum(attr) + n.VmType.GetAttrValue(attr)
 }
+
+// diversified checks whether the request limits the share of instance families or generations in the spot capacity
+func diversified(req recommender.SingleClusterRecommendationReq) bool {
+	return req.MaxSpotFamilyPct > 0 || req.MaxSpotGenerationPct > 0
+}
+
+// spreadAcrossFamilies reorders the (sorted) vms so that the cheapest vm of every family comes first, then the second cheapest etc.
+func spreadAcrossFamilies(vms []recommender.VirtualMachine) []recommender.VirtualMachine {
+	rounds := make([][]recommender.VirtualMachine, 0)
+	familyCount := make(map[string]int)
+	for _, vm := range vms {
+		family := recommender.InstanceFamily(vm.Type)
+		round := familyCount[family]
+		familyCount[family]++
+		if round == len(rounds) {
+			rounds = append(rounds, make([]recommender.VirtualMachine, 0))
+		}
+		rounds[round] = append(rounds[round], vm)
+	}
+
+	spread := make([]recommender.VirtualMachine, 0, len(vms))
+	for _, round := range rounds {
+		spread = append(spread, round...)
+	}
+	return spread
+}
+
+// fillDiversifiedSpotNodePools adds nodes always to the smallest node pool that keeps its instance family and generation
+// under the requested share of the spot capacity; the first n pools are used while possible, the rest only when the caps require
+func (s *nodePoolSelector) fillDiversifiedSpotNodePools(sumSpotValue float64, n int, nps []recommender.NodePool, attr string,
+	req recommender.SingleClusterRecommendationReq,
+) []recommender.NodePool {
+	var sumValueInPools float64
+	familyValues := make(map[string]float64)
+	generationValues := make(map[string]float64)
+	for _, np := range nps {
+		v := np.GetSum(attr)
+		sumValueInPools += v
+		familyValues[recommender.InstanceFamily(np.VmType.Type)] += v
+		generationValues[recommender.InstanceGeneration(np.VmType.Type)] += v
+	}
+	desiredSpotValue := sumValueInPools + sumSpotValue
+	maxFamilyValue := desiredSpotValue * float64(req.MaxSpotFamilyPct) / 100
+	maxGenerationValue := desiredSpotValue * float64(req.MaxSpotGenerationPct) / 100
+
+	withinCaps := func(np recommender.NodePool) bool {
+		v := np.VmType.GetAttrValue(attr)
+		generation := recommender.InstanceGeneration(np.VmType.Type)
+		if req.MaxSpotFamilyPct > 0 && familyValues[recommender.InstanceFamily(np.VmType.Type)]+v > maxFamilyValue {
+			return false
+		}
+		if req.MaxSpotGenerationPct > 0 && generation != "" && generationValues[generation]+v > maxGenerationValue {
+			return false
+		}
+		return true
+	}
+
+	if len(nps) == 0 {
+		return nps
+	}
+	active := int(math.Min(float64(n), float64(len(nps))))
+	capsViolated := false
+	for sumValueInPools < desiredSpotValue {
+		selected := -1
+		for i := 0; i < active; i++ {
+			if (capsViolated || withinCaps(nps[i])) && (selected < 0 || nps[i].GetSum(attr) < nps[selected].GetSum(attr)) {
+				selected = i
+			}
+		}
+		if selected < 0 {
+			if active < len(nps) {
+				// involve the next cheapest pool to keep the shares under the caps
+				active++
+			} else {
+				s.log.Warn("could not keep the instance families and generations under the requested share of the spot capacity")
+				capsViolated = true
+			}
+			continue
+		}
+
+		v := nps[selected].VmType.GetAttrValue(attr)
+		nps[selected].SumNodes += 1
+		sumValueInPools += v
+		familyValues[recommender.InstanceFamily(nps[selected].VmType.Type)] += v
+		generationValues[recommender.InstanceGeneration(nps[selected].VmType.Type)] += v
+		s.log.Debug(fmt.Sprintf("adding vm to the [%d]th node pool, sum value in pools: [%f]", selected, sumValueInPools))
+	}
+	return nps
+}
@@ -15,8 +15,11 @@
 package nodepools
 
 import (
+	"context"
 	"testing"
 
+	"github.com/banzaicloud/telescopes/pkg/recommender"
+	"github.com/goph/logur"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -63,3 +66,163 @@ func Test_avgSpotNodeCount(t *testing.T) {
 		})
 	}
 }
+
+func Test_spreadAcrossFamilies(t *testing.T) {
+	vms := []recommender.VirtualMachine{
+		{Type: "m5.large"},
+		{Type: "m5.xlarge"},
+		{Type: "m5.2xlarge"},
+		{Type: "c5.large"},
+		{Type: "r5.large"},
+		{Type: "c5.xlarge"},
+	}
+
+	var types []string
+	for _, vm := range spreadAcrossFamilies(vms) {
+		types = append(types, vm.Type)
+	}
+	assert.Equal(t, []string{"m5.large", "c5.large", "r5.large", "m5.xlarge", "c5.xlarge", "m5.2xlarge"}, types)
+}
+
+func TestNodePoolSelector_fillDiversifiedSpotNodePools(t *testing.T) {
+	tests := []struct {
+		name  string
+		nps   []recommender.NodePool
+		n     int
+		req   recommender.SingleClusterRecommendationReq
+		check func(nps []recommender.NodePool)
+	}{
+		{
+			name: "family share is capped",
+			nps: []recommender.NodePool{
+				{VmType: recommender.VirtualMachine{Type: "m5.large", Cpus: 2}, VmClass: recommender.Spot},
+				{VmType: recommender.VirtualMachine{Type: "m5.xlarge", Cpus: 4}, VmClass: recommender.Spot},
+				{VmType: recommender.VirtualMachine{Type: "c5.large", Cpus: 2}, VmClass: recommender.Spot},
+			},
+			n: 2,
+			req: recommender.SingleClusterRecommendationReq{
+				ClusterRecommendationReq: recommender.ClusterRecommendationReq{MaxSpotFamilyPct: 50},
+			},
+			check: func(nps []recommender.NodePool) {
+				assert.Equal(t, float64(8), nps[0].GetSum(recommender.Cpu)+nps[1].GetSum(recommender.Cpu))
+				assert.Equal(t, float64(8), nps[2].GetSum(recommender.Cpu))
+			},
+		},
+		{
+			name: "caps are violated when there are no other families",
+			nps: []recommender.NodePool{
+				{VmType: recommender.VirtualMachine{Type: "m5.large", Cpus: 2}, VmClass: recommender.Spot},
+				{VmType: recommender.VirtualMachine{Type: "m5.xlarge", Cpus: 4}, VmClass: recommender.Spot},
+			},
+			n: 2,
+			req: recommender.SingleClusterRecommendationReq{
+				ClusterRecommendationReq: recommender.ClusterRecommendationReq{MaxSpotGenerationPct: 50},
+			},
+			check: func(nps []recommender.NodePool) {
+				assert.True(t, nps[0].GetSum(recommender.Cpu)+nps[1].GetSum(recommender.Cpu) >= 16)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test // pin - scopelint
+		t.Run(test.name, func(t *testing.T) {
+			selector := NewNodePoolSelector(logur.NewTestLogger())
+			test.check(selector.fillDiversifiedSpotNodePools(16, test.n, test.nps, recommender.Cpu, test.req))
+		})
+	}
+}
+
+func TestNodePoolSelector_RecommendNodePools(t *testing.T) {
+	odVms := []recommender.VirtualMachine{{Type: "m5.xlarge", Cpus: 4, Mem: 16, OnDemandPrice: 0.2}}
+	spotVms := []recommender.VirtualMachine{{Type: "m5.xlarge", Cpus: 4, Mem: 16, AvgPrice: 0.07}}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		req     recommender.SingleClusterRecommendationReq
+		layout  []recommender.NodePool
+		spotVms []recommender.VirtualMachine
+		check   func(nps []recommender.NodePool, err error)
+	}{
+		{
+			name: "on-demand node pools cover the requested share",
+			ctx:  context.Background(),
+			req: recommender.SingleClusterRecommendationReq{
+				ClusterRecommendationReq: recommender.ClusterRecommendationReq{
+					MinNodes:    1,
+					MaxNodes:    4,
+					SumCpu:      16,
+					OnDemandPct: 100,
+				},
+			},
+			spotVms: spotVms,
+			check: func(nps []recommender.NodePool, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, float64(16), nps[0].GetSum(recommender.Cpu))
+			},
+		},
+		{
+			name: "on-demand nodes exceeding the average node count",
+			ctx:  context.Background(),
+			req: recommender.SingleClusterRecommendationReq{
+				ClusterRecommendationReq: recommender.ClusterRecommendationReq{
+					MinNodes:    1,
+					MaxNodes:    1,
+					SumCpu:      8,
+					OnDemandPct: 50,
+				},
+			},
+			spotVms: spotVms,
+			check: func(nps []recommender.NodePool, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 2, len(nps))
+				assert.Equal(t, recommender.Spot, nps[1].VmClass)
+				assert.Equal(t, float64(4), nps[1].GetSum(recommender.Cpu))
+			},
+		},
+		{
+			name: "spot capacity without spot node pools",
+			ctx:  context.Background(),
+			req: recommender.SingleClusterRecommendationReq{
+				ClusterRecommendationReq: recommender.ClusterRecommendationReq{
+					MinNodes:    1,
+					MaxNodes:    4,
+					SumCpu:      8,
+					OnDemandPct: 50,
+				},
+			},
+			layout: []recommender.NodePool{
+				{VmType: spotVms[0], SumNodes: 1, VmClass: recommender.Spot},
+			},
+			check: func(nps []recommender.NodePool, err error) {
+				assert.EqualError(t, err, "no spot node pool to cover the spot capacity")
+				assert.Nil(t, nps)
+			},
+		},
+		{
+			name: "cancelled request",
+			ctx:  cancelled,
+			req: recommender.SingleClusterRecommendationReq{
+				ClusterRecommendationReq: recommender.ClusterRecommendationReq{
+					MinNodes:    1,
+					MaxNodes:    4,
+					SumCpu:      16,
+					OnDemandPct: 100,
+				},
+			},
+			spotVms: spotVms,
+			check: func(nps []recommender.NodePool, err error) {
+				assert.Equal(t, context.Canceled, err)
+				assert.Nil(t, nps)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test // pin - scopelint
+		t.Run(test.name, func(t *testing.T) {
+			selector := NewNodePoolSelector(logur.NewTestLogger())
+			test.check(selector.RecommendNodePools(test.ctx, recommender.Cpu, test.req, test.layout, odVms, test.spotVms))
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goph/emperror"
+	"github.com/pkg/errors"
+)
+
+// onDemandRatioSearched checks whether the on-demand percentage of the request is to be reached by searching
+// for the share of on-demand resources that results in the requested share of cost or nodes
+func onDemandRatioSearched(req SingleClusterRecommendationReq) bool {
+	if req.OnDemandPct <= 0 || req.OnDemandPct >= 100 {
+		// all-spot and all-on-demand layouts are the same in every interpretation
+		return false
+	}
+	return req.OnDemandPctMode == OnDemandPctCost || req.OnDemandPctMode == OnDemandPctNodes
+}
+
+// getOnDemandRatioNodePoolSet looks for the smallest share of on-demand resources that results in a layout
+// satisfying the requested on-demand share of cost or nodes; the layout is recommended for every tried share, the
+// shares failing to be recommended are skipped and the last failure is returned if none of them succeeds
+func (e *Engine) getOnDemandRatioNodePoolSet(ctx context.Context, provider string, req SingleClusterRecommendationReq, layoutDesc []NodePoolDesc,
+	allProducts []VirtualMachine,
+) ([]NodePool, error) {
+	resourceReq := req
+	resourceReq.OnDemandPctMode = OnDemandPctResources
+
+	var (
+		selected []NodePool
+		lastErr  error
+	)
+	// the on-demand share of cost and nodes grows with the share of on-demand resources
+	low, high := 0, 100
+	for low <= high {
+		resourceReq.OnDemandPct = (low + high) / 2
+		nodePools, err := e.getCheapestNodePoolSet(ctx, provider, resourceReq, layoutDesc, allProducts)
+		if err != nil {
+			lastErr = err
+			low = resourceReq.OnDemandPct + 1
+			continue
+		}
+
+		ratio := onDemandRatio(req.OnDemandPctMode, nodePools)
+		e.log.Debug(fmt.Sprintf("on-demand resource percentage [%d] results in [%s] percentage [%f]",
+			resourceReq.OnDemandPct, req.OnDemandPctMode, ratio))
+		if ratio >= float64(req.OnDemandPct) {
+			selected = nodePools
+			high = resourceReq.OnDemandPct - 1
+		} else {
+			low = resourceReq.OnDemandPct + 1
+		}
+	}
+
+	if selected == nil {
+		if lastErr != nil {
+			return nil, lastErr
+		}
+		return nil, emperror.With(errors.Errorf("could not recommend a cluster with %d%% on-demand %s", req.OnDemandPct,
+			req.OnDemandPctMode), RecommenderErrorTag)
+	}
+	return selected, nil
+}
+
+// onDemandRatio returns the on-demand percentage of the worker node pools by cost or by node count
+func onDemandRatio(mode string, nodePools []NodePool) float64 {
+	var odValue, sumValue float64
+	for _, np := range nodePools {
+		if np.Role == Master {
+			continue
+		}
+		value := float64(np.SumNodes)
+		if mode == OnDemandPctCost {
+			value = np.PoolPrice()
+		}
+		if np.VmClass == Regular {
+			odValue += value
+		}
+		sumValue += value
+	}
+	if sumValue == 0 {
+		return 0
+	}
+	return odValue / sumValue * 100
+}
@@ -0,0 +1,122 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goph/logur"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type ratioVms struct {
+	dummyVms
+}
+
+func (v *ratioVms) RecommendVms(ctx context.Context, provider string, vms []VirtualMachine, attr string, req SingleClusterRecommendationReq, layout []NodePool) ([]VirtualMachine, []VirtualMachine, error) {
+	return []VirtualMachine{{Cpus: 1, Mem: 1, OnDemandPrice: 3}}, []VirtualMachine{{Cpus: 1, Mem: 1, AvgPrice: 1}}, nil
+}
+
+// ratioNodePools recommends 10 nodes, the share of on-demand nodes follows the on-demand percentage of the request
+type ratioNodePools struct {
+	// the recommendation fails below this on-demand percentage
+	failBelow int
+}
+
+func (nps *ratioNodePools) RecommendNodePools(ctx context.Context, attr string, req SingleClusterRecommendationReq, layout []NodePool, odVms []VirtualMachine, spotVms []VirtualMachine) ([]NodePool, error) {
+	if req.OnDemandPct < nps.failBelow {
+		return nil, errors.New("no spot node pool to cover the spot capacity")
+	}
+	odNodes := req.OnDemandPct / 10
+	return []NodePool{
+		{VmType: odVms[0], SumNodes: odNodes, VmClass: Regular, Role: Worker},
+		{VmType: spotVms[0], SumNodes: 10 - odNodes, VmClass: Spot, Role: Worker},
+	}, nil
+}
+
+func TestEngine_getOnDemandRatioNodePoolSet(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      string
+		failBelow int
+		check     func(nps []NodePool, err error)
+	}{
+		{
+			name: "on-demand percentage by cost",
+			mode: OnDemandPctCost,
+			check: func(nps []NodePool, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				// 3 on-demand nodes cost 9 out of 16
+				assert.Equal(t, 3, nps[0].SumNodes)
+				assert.True(t, onDemandRatio(OnDemandPctCost, nps) >= 50)
+			},
+		},
+		{
+			name: "on-demand percentage by node count",
+			mode: OnDemandPctNodes,
+			check: func(nps []NodePool, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 5, nps[0].SumNodes)
+			},
+		},
+		{
+			name:      "failed candidates are skipped",
+			mode:      OnDemandPctNodes,
+			failBelow: 70,
+			check: func(nps []NodePool, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 7, nps[0].SumNodes)
+			},
+		},
+		{
+			name:      "every candidate fails",
+			mode:      OnDemandPctNodes,
+			failBelow: 101,
+			check: func(nps []NodePool, err error) {
+				assert.EqualError(t, err, "failed to recommend node pools: no spot node pool to cover the spot capacity")
+				assert.Nil(t, nps)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			engine := NewEngine(logur.NewTestLogger(), nil, &ratioVms{}, &ratioNodePools{failBelow: test.failBelow})
+			req := SingleClusterRecommendationReq{
+				ClusterRecommendationReq: ClusterRecommendationReq{
+					SumCpu:          10,
+					SumMem:          10,
+					MinNodes:        1,
+					MaxNodes:        10,
+					OnDemandPct:     50,
+					OnDemandPctMode: test.mode,
+				},
+			}
+			test.check(engine.getCheapestNodePoolSet(context.Background(), "dummyProvider", req, nil, nil))
+		})
+	}
+}
+
+func Test_onDemandRatio(t *testing.T) {
+	nodePools := []NodePool{
+		{VmType: VirtualMachine{OnDemandPrice: 3}, SumNodes: 1, VmClass: Regular, Role: Worker},
+		{VmType: VirtualMachine{AvgPrice: 1}, SumNodes: 3, VmClass: Spot, Role: Worker},
+		{VmType: VirtualMachine{OnDemandPrice: 10}, SumNodes: 1, VmClass: Regular, Role: Master},
+	}
+	assert.Equal(t, float64(50), onDemandRatio(OnDemandPctCost, nodePools))
+	assert.Equal(t, float64(25), onDemandRatio(OnDemandPctNodes, nodePools))
+}
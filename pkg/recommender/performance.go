@@ -0,0 +1,63 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+// normalizedProducts returns a copy of the products with their cpus replaced by their compute units,
+// so that the node pools are sized and the instance types are ranked by performance-normalized capacity
+func normalizedProducts(products []VirtualMachine) []VirtualMachine {
+	normalized := make([]VirtualMachine, len(products))
+	for i, vm := range products {
+		normalized[i] = vm
+		normalized[i].Cpus = vm.ComputeUnits()
+	}
+	return normalized
+}
+
+// withRawVmTypes sets back the original instance types of the node pools after sizing with adjusted products,
+// eg. with compute units instead of vCPUs or with license prices included
+func withRawVmTypes(nodePools []NodePool, products []VirtualMachine) []NodePool {
+	vms := make(map[string]VirtualMachine, len(products))
+	for _, vm := range products {
+		vms[vm.Type] = vm
+	}
+
+	nps := make([]NodePool, len(nodePools))
+	for i, np := range nodePools {
+		nps[i] = np
+		if vm, ok := vms[np.VmType.Type]; ok {
+			nps[i].VmType = vm
+		}
+	}
+	return nps
+}
+
+// performanceFactorsAvailable checks whether the performance factor of any of the products is known
+func performanceFactorsAvailable(products []VirtualMachine) bool {
+	for _, vm := range products {
+		if vm.PerfFactor > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// computeUnits returns the performance-normalized cpu capacity of the node pools
+func computeUnits(nodePools []NodePool) float64 {
+	var units float64
+	for _, np := range nodePools {
+		units += float64(np.SumNodes) * np.VmType.ComputeUnits()
+	}
+	return units
+}
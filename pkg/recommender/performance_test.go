@@ -0,0 +1,43 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_normalizedProducts(t *testing.T) {
+	products := []VirtualMachine{
+		{Type: "c5.xlarge", Cpus: 4, PerfFactor: 1.5},
+		{Type: "t2.xlarge", Cpus: 4, PerfFactor: 0.5},
+		{Type: "m5.xlarge", Cpus: 4},
+	}
+
+	normalized := normalizedProducts(products)
+	assert.Equal(t, float64(6), normalized[0].Cpus)
+	assert.Equal(t, float64(2), normalized[1].Cpus)
+	assert.Equal(t, float64(4), normalized[2].Cpus)
+	assert.Equal(t, float64(4), products[0].Cpus, "the products should not be changed")
+
+	nodePools := withRawVmTypes([]NodePool{
+		{VmType: normalized[0], SumNodes: 2},
+		{VmType: normalized[1], SumNodes: 1},
+	}, products)
+	assert.Equal(t, float64(4), nodePools[0].VmType.Cpus)
+	assert.Equal(t, float64(4), nodePools[1].VmType.Cpus)
+	assert.Equal(t, float64(14), computeUnits(nodePools))
+}
@@ -0,0 +1,98 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"fmt"
+
+	"github.com/goph/emperror"
+	"github.com/pkg/errors"
+)
+
+// ensurePodCapacity adds nodes to the worker pool with the lowest price per pod until the worker nodes can host the pods,
+// only pools that keep the number of worker nodes within the maximum (if set) are considered
+func (e *Engine) ensurePodCapacity(nodePools []NodePool, sumPods, maxPodsPerNode, maxNodes int) ([]NodePool, error) {
+	var workerNodes int
+	limited := false
+	for _, np := range nodePools {
+		if np.Role == Master {
+			continue
+		}
+		workerNodes += np.SumNodes
+		if np.SumNodes > 0 && podsPerNode(np, maxPodsPerNode) > 0 {
+			limited = true
+		}
+	}
+	if !limited {
+		return nil, emperror.With(errors.New("the maximum number of pods is unknown for the recommended instance types, "+
+			"sumPods can't be applied"), RecommenderErrorTag)
+	}
+
+	missingPods := sumPods - podCapacity(nodePools, maxPodsPerNode)
+	if missingPods <= 0 {
+		return nodePools, nil
+	}
+
+	cheapest, nodesToAdd := -1, 0
+	for i, np := range nodePools {
+		if np.Role == Master || np.SumNodes == 0 || podsPerNode(np, maxPodsPerNode) == 0 {
+			continue
+		}
+		perNode := podsPerNode(np, maxPodsPerNode)
+		nodes := (missingPods + perNode - 1) / perNode
+		if maxNodes > 0 && workerNodes+nodes > maxNodes {
+			continue
+		}
+		if cheapest < 0 || pricePerPod(np, maxPodsPerNode) < pricePerPod(nodePools[cheapest], maxPodsPerNode) {
+			cheapest, nodesToAdd = i, nodes
+		}
+	}
+	if cheapest < 0 {
+		return nil, emperror.With(errors.Errorf("hosting %d pods requires more worker nodes than the maximum of %d", sumPods, maxNodes),
+			RecommenderErrorTag)
+	}
+
+	nps := make([]NodePool, len(nodePools))
+	copy(nps, nodePools)
+	nps[cheapest].SumNodes += nodesToAdd
+	e.log.Debug(fmt.Sprintf("adding [%d] nodes of type [%s] to host [%d] pods", nodesToAdd, nps[cheapest].VmType.Type, sumPods))
+
+	return nps, nil
+}
+
+// podCapacity returns the number of pods the worker nodes with a known pod limit can host
+func podCapacity(nodePools []NodePool, maxPodsPerNode int) int {
+	var pods int
+	for _, np := range nodePools {
+		if np.Role != Master {
+			pods += np.SumNodes * podsPerNode(np, maxPodsPerNode)
+		}
+	}
+	return pods
+}
+
+// podsPerNode returns the pod limit of the node pool's nodes, the configured limit takes precedence over the instance type's
+func podsPerNode(np NodePool, maxPodsPerNode int) int {
+	if maxPodsPerNode > 0 {
+		return maxPodsPerNode
+	}
+	return np.VmType.MaxPods
+}
+
+func pricePerPod(np NodePool, maxPodsPerNode int) float64 {
+	node := np
+	node.SumNodes = 1
+	return node.PoolPrice() / float64(podsPerNode(np, maxPodsPerNode))
+}
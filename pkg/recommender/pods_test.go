@@ -0,0 +1,111 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"testing"
+
+	"github.com/goph/logur"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEngine_ensurePodCapacity(t *testing.T) {
+	nodePools := []NodePool{
+		{VmType: VirtualMachine{OnDemandPrice: 2, MaxPods: 10}, SumNodes: 2, VmClass: Regular, Role: Worker},
+		{VmType: VirtualMachine{AvgPrice: 1, MaxPods: 20}, SumNodes: 1, VmClass: Spot, Role: Worker},
+		{VmType: VirtualMachine{OnDemandPrice: 1, MaxPods: 100}, SumNodes: 1, VmClass: Regular, Role: Master},
+	}
+	tests := []struct {
+		name           string
+		nodePools      []NodePool
+		sumPods        int
+		maxPodsPerNode int
+		maxNodes       int
+		check          func(nps []NodePool, err error)
+	}{
+		{
+			name:    "enough pod capacity",
+			sumPods: 40,
+			check: func(nps []NodePool, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 2, nps[0].SumNodes)
+				assert.Equal(t, 1, nps[1].SumNodes)
+			},
+		},
+		{
+			name:    "nodes are added to the pool with the lowest price per pod",
+			sumPods: 75,
+			check: func(nps []NodePool, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 2, nps[0].SumNodes)
+				assert.Equal(t, 3, nps[1].SumNodes)
+				assert.Equal(t, 80, podCapacity(nps, 0))
+			},
+		},
+		{
+			name:           "configured pod limit",
+			sumPods:        75,
+			maxPodsPerNode: 5,
+			check: func(nps []NodePool, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 2, nps[0].SumNodes)
+				assert.Equal(t, 13, nps[1].SumNodes)
+			},
+		},
+		{
+			name: "nodes are added to a pool keeping the node count within the maximum",
+			nodePools: []NodePool{
+				{VmType: VirtualMachine{OnDemandPrice: 1, MaxPods: 10}, SumNodes: 1, VmClass: Regular, Role: Worker},
+				{VmType: VirtualMachine{OnDemandPrice: 4, MaxPods: 30}, SumNodes: 1, VmClass: Regular, Role: Worker},
+			},
+			sumPods:  100,
+			maxNodes: 5,
+			check: func(nps []NodePool, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 1, nps[0].SumNodes)
+				assert.Equal(t, 3, nps[1].SumNodes)
+			},
+		},
+		{
+			name:     "pods exceeding the maximum number of nodes",
+			sumPods:  200,
+			maxNodes: 5,
+			check: func(nps []NodePool, err error) {
+				assert.EqualError(t, err, "hosting 200 pods requires more worker nodes than the maximum of 5")
+			},
+		},
+		{
+			name: "unknown pod limits",
+			nodePools: []NodePool{
+				{VmType: VirtualMachine{OnDemandPrice: 1}, SumNodes: 2, VmClass: Regular, Role: Worker},
+			},
+			sumPods: 40,
+			check: func(nps []NodePool, err error) {
+				assert.EqualError(t, err, "the maximum number of pods is unknown for the recommended instance types, sumPods can't be applied")
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			nps := nodePools
+			if test.nodePools != nil {
+				nps = test.nodePools
+			}
+			engine := NewEngine(logur.NewTestLogger(), nil, nil, nil)
+			test.check(engine.ensurePodCapacity(nps, test.sumPods, test.maxPodsPerNode, test.maxNodes))
+		})
+	}
+}
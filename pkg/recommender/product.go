@@ -16,54 +16,73 @@ package recommender
 
 import (
 	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/banzaicloud/telescopes/.gen/cloudinfo"
 	"github.com/go-openapi/runtime"
 	"github.com/goph/emperror"
 	"github.com/goph/logur"
+	"github.com/pkg/errors"
 )
 
 // CloudInfoSource declares operations for retrieving information required for the recommender engine
 type CloudInfoSource interface {
 	// GetProductDetails retrieves the product details for the provider and region
-	GetProductDetails(provider string, service string, region string) ([]VirtualMachine, error)
+	GetProductDetails(ctx context.Context, provider string, service string, region string) ([]VirtualMachine, error)
 
 	// GetRegions retrieves the regions
-	GetRegions(provider, service string) ([]cloudinfo.Region, error)
+	GetRegions(ctx context.Context, provider, service string) ([]cloudinfo.Region, error)
 
 	// GetContinentsData retrieves continents data
-	GetContinentsData(provider, service string) ([]cloudinfo.Continent, error)
+	GetContinentsData(ctx context.Context, provider, service string) ([]cloudinfo.Continent, error)
 
 	// GetZones retrieves zones
-	GetZones(provider, service, region string) ([]string, error)
+	GetZones(ctx context.Context, provider, service, region string) ([]string, error)
 
 	// GetContinents retrieves supported continents
-	GetContinents() ([]string, error)
+	GetContinents(ctx context.Context) ([]string, error)
 
 	// GetRegion retrieves the region for the provided arguments, returns error if not found
-	GetRegion(provider string, service string, region string) (string, error)
+	GetRegion(ctx context.Context, provider string, service string, region string) (string, error)
 
 	// GetProvider retrieves the given provider,returns error if not found
-	GetProvider(provider string) (string, error)
+	GetProvider(ctx context.Context, provider string) (string, error)
 
 	// GetService  retrieves the given service, returns error if not found
-	GetService(provider string, service string) (string, error)
+	GetService(ctx context.Context, provider string, service string) (string, error)
+
+	// GetImages retrieves the node images available in the region
+	GetImages(ctx context.Context, provider string, service string, region string) ([]cloudinfo.Image, error)
+
+	// GetVersions retrieves the Kubernetes versions offered in the locations of the region
+	GetVersions(ctx context.Context, provider string, service string, region string) ([]cloudinfo.LocationVersion, error)
 }
 
 // cloudInfoClient component struct to retrieve data for the recommender; wraps the generated product info client
 // It implements the CloudInfoSource interface, delegates to the embedded generated client
 type cloudInfoClient struct {
 	logger logur.Logger
+	// deadline of the calls to the cloud info service, no deadline if 0
+	timeout time.Duration
 	*cloudinfo.APIClient
 }
 
 const (
 	cloudInfoService         = "cloud-info"
 	cloudInfoClientComponent = "cloud-info-client"
+	// product attribute describing the local disks of the instance type
+	storageAttribute = "storage"
+	// product attribute holding the on demand price of the instance type with Windows, if cloud info provides it
+	windowsPriceAttribute = "windowsOnDemandPrice"
 )
 
 // NewCloudInfoClient creates a new product info client wrapper instance
-func NewCloudInfoClient(ciUrl string, logger logur.Logger) CloudInfoSource {
+func NewCloudInfoClient(ciUrl string, timeout time.Duration, logger logur.Logger) CloudInfoSource {
 	apiCli := cloudinfo.NewAPIClient(&cloudinfo.Configuration{
 		BasePath:      ciUrl,
 		DefaultHeader: make(map[string]string),
@@ -71,44 +90,67 @@ func NewCloudInfoClient(ciUrl string, logger logur.Logger) CloudInfoSource {
 	})
 	return &cloudInfoClient{
 		APIClient: apiCli,
+		timeout:   timeout,
 		logger:    logur.WithFields(logger, map[string]interface{}{"cli": cloudInfoClientComponent}),
 	}
 }
 
+// withTimeout returns the context of a call to the cloud info service
+func (ciCli *cloudInfoClient) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if ciCli.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, ciCli.timeout)
+}
+
 // GetProductDetails gets the available product details from the provider in the region
-func (ciCli *cloudInfoClient) GetProductDetails(provider string, service string, region string) ([]VirtualMachine, error) {
+func (ciCli *cloudInfoClient) GetProductDetails(ctx context.Context, provider string, service string, region string) ([]VirtualMachine, error) {
 	tags := map[string]interface{}{"provider": provider, "service": service, "region": region}
 	ciCli.logger.Info("retrieving product details", tags)
 
-	allProducts, _, err := ciCli.ProductsApi.GetProducts(context.Background(), provider, service, region)
+	ctx, cancel := ciCli.withTimeout(ctx)
+	defer cancel()
+
+	allProducts, resp, err := ciCli.ProductsApi.GetProducts(ctx, provider, service, region)
 	if err != nil {
 		ciCli.logger.Error("failed to retrieve product details", tags)
-		return nil, discriminateErrCtx(err)
+		return nil, discriminateErrCtx(resp, err)
 	}
 
 	vms := make([]VirtualMachine, 0)
 
 	for _, p := range allProducts.Products {
-		vms = append(vms, VirtualMachine{
-			Category:       p.Category,
-			Type:           p.Type,
-			OnDemandPrice:  p.OnDemandPrice,
-			AvgPrice:       avg(p.SpotPrice),
-			Cpus:           p.CpusPerVm,
-			Mem:            p.MemPerVm,
-			Gpus:           p.GpusPerVm,
-			Burst:          p.Burst,
-			NetworkPerf:    p.NtwPerf,
-			NetworkPerfCat: p.NtwPerfCategory,
-			CurrentGen:     p.CurrentGen,
-			Zones:          p.Zones,
-		})
+		vms = append(vms, newVirtualMachine(p))
 	}
 
 	ciCli.logger.Info("retrieved product details", tags)
 	return vms, nil
 }
 
+// newVirtualMachine converts the cloud info product details of an instance type
+func newVirtualMachine(p cloudinfo.ProductDetails) VirtualMachine {
+	localStorage, localStorageType := parseLocalStorage(p.Attributes)
+	return VirtualMachine{
+		Category:         p.Category,
+		Type:             p.Type,
+		OnDemandPrice:    p.OnDemandPrice,
+		AvgPrice:         avg(p.SpotPrice),
+		Cpus:             p.CpusPerVm,
+		Mem:              p.MemPerVm,
+		Gpus:             p.GpusPerVm,
+		Burst:            p.Burst,
+		NetworkPerf:      p.NtwPerf,
+		NetworkPerfCat:   p.NtwPerfCategory,
+		NetworkGbps:      parseNetworkGbps(p.NtwPerf),
+		LocalStorage:     localStorage,
+		LocalStorageType: localStorageType,
+		CurrentGen:       p.CurrentGen,
+		Zones:            p.Zones,
+
+		WindowsLicensePrice: parseWindowsLicensePrice(p.Attributes, p.OnDemandPrice),
+	}
+}
+
 func avg(prices []cloudinfo.ZonePrice) float64 {
 	if len(prices) == 0 {
 		return 0.0
@@ -120,15 +162,78 @@ func avg(prices []cloudinfo.ZonePrice) float64 {
 	return avgPrice / float64(len(prices))
 }
 
+var (
+	networkBandwidthRegexp = regexp.MustCompile(`(?i)([0-9]+(?:\.[0-9]+)?)\s*(gigabit|gbit|gbps|gb|megabit|mbit|mbps|mb)`)
+	localStorageRegexp     = regexp.MustCompile(`(?i)([0-9]+)\s*x\s*([0-9,]+(?:\.[0-9]+)?)\s*(?:gb\s*)?(nvme ssd|nvme|ssd|hdd)?`)
+)
+
+// parseNetworkGbps parses the bandwidth from the network performance of the instance type (eg. 10 Gigabit, 16 Gbit/s),
+// burstable bandwidth (eg. Up to 10 Gigabit) counts with its peak value; returns 0 for categories without a number (eg. Moderate)
+func parseNetworkGbps(ntwPerf string) float64 {
+	parts := networkBandwidthRegexp.FindStringSubmatch(ntwPerf)
+	if parts == nil {
+		return 0
+	}
+	bandwidth, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return 0
+	}
+	if strings.HasPrefix(strings.ToLower(parts[2]), "m") {
+		return bandwidth / 1000
+	}
+	return bandwidth
+}
+
+// parseLocalStorage parses the capacity (GB) and type of the local disks from the storage attribute of the
+// instance type (eg. 2 x 900 NVMe SSD); returns 0 for instance types without local disks (eg. EBS only)
+func parseLocalStorage(attributes map[string]string) (float64, string) {
+	parts := localStorageRegexp.FindStringSubmatch(attributes[storageAttribute])
+	if parts == nil {
+		return 0, ""
+	}
+	disks, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return 0, ""
+	}
+	size, err := strconv.ParseFloat(strings.Replace(parts[2], ",", "", -1), 64)
+	if err != nil {
+		return 0, ""
+	}
+
+	var storageType string
+	switch diskType := strings.ToLower(parts[3]); {
+	case strings.Contains(diskType, StorageNvme):
+		storageType = StorageNvme
+	case diskType == StorageSsd:
+		storageType = StorageSsd
+	case diskType == StorageHdd:
+		storageType = StorageHdd
+	}
+	return disks * size, storageType
+}
+
+// parseWindowsLicensePrice returns the difference between the Windows and the Linux on demand price of the instance type,
+// 0 if cloud info doesn't provide a Windows price
+func parseWindowsLicensePrice(attributes map[string]string, onDemandPrice float64) float64 {
+	windowsPrice, err := strconv.ParseFloat(attributes[windowsPriceAttribute], 64)
+	if err != nil || windowsPrice <= onDemandPrice {
+		return 0
+	}
+	return windowsPrice - onDemandPrice
+}
+
 // GetProvider validates provider
-func (ciCli *cloudInfoClient) GetProvider(prv string) (string, error) {
+func (ciCli *cloudInfoClient) GetProvider(ctx context.Context, prv string) (string, error) {
 	tags := map[string]interface{}{"provider": prv}
 	ciCli.logger.Info("retrieving provider", tags)
 
-	provider, _, err := ciCli.ProviderApi.GetProvider(context.Background(), prv)
+	ctx, cancel := ciCli.withTimeout(ctx)
+	defer cancel()
+
+	provider, resp, err := ciCli.ProviderApi.GetProvider(ctx, prv)
 	if err != nil {
 		ciCli.logger.Error("failed to retrieve provider", tags)
-		return "", discriminateErrCtx(err)
+		return "", discriminateErrCtx(resp, err)
 	}
 
 	ciCli.logger.Info("retrieved provider", tags)
@@ -136,14 +241,17 @@ func (ciCli *cloudInfoClient) GetProvider(prv string) (string, error) {
 }
 
 // GetService validates service
-func (ciCli *cloudInfoClient) GetService(prv string, svc string) (string, error) {
+func (ciCli *cloudInfoClient) GetService(ctx context.Context, prv string, svc string) (string, error) {
 	tags := map[string]interface{}{"provider": prv, "service": svc}
 	ciCli.logger.Info("retrieving service", tags)
 
-	service, _, err := ciCli.ServiceApi.GetService(context.Background(), prv, svc)
+	ctx, cancel := ciCli.withTimeout(ctx)
+	defer cancel()
+
+	service, resp, err := ciCli.ServiceApi.GetService(ctx, prv, svc)
 	if err != nil {
 		ciCli.logger.Error("failed to retrieve service", tags)
-		return "", discriminateErrCtx(err)
+		return "", discriminateErrCtx(resp, err)
 	}
 
 	ciCli.logger.Info("retrieved service", tags)
@@ -151,14 +259,17 @@ func (ciCli *cloudInfoClient) GetService(prv string, svc string) (string, error)
 }
 
 // GetRegion validates region
-func (ciCli *cloudInfoClient) GetRegion(prv, svc, reg string) (string, error) {
+func (ciCli *cloudInfoClient) GetRegion(ctx context.Context, prv, svc, reg string) (string, error) {
 	tags := map[string]interface{}{"provider": prv, "service": svc, "region": reg}
 	ciCli.logger.Info("retrieving region", tags)
 
-	r, _, err := ciCli.RegionApi.GetRegion(context.Background(), prv, svc, reg)
+	ctx, cancel := ciCli.withTimeout(ctx)
+	defer cancel()
+
+	r, resp, err := ciCli.RegionApi.GetRegion(ctx, prv, svc, reg)
 	if err != nil {
 		ciCli.logger.Error("failed to retrieve region", tags)
-		return "", discriminateErrCtx(err)
+		return "", discriminateErrCtx(resp, err)
 	}
 
 	ciCli.logger.Info("retrieved region", tags)
@@ -166,14 +277,17 @@ func (ciCli *cloudInfoClient) GetRegion(prv, svc, reg string) (string, error) {
 }
 
 // GetZones get zones
-func (ciCli *cloudInfoClient) GetZones(provider, service, region string) ([]string, error) {
+func (ciCli *cloudInfoClient) GetZones(ctx context.Context, provider, service, region string) ([]string, error) {
 	tags := map[string]interface{}{"provider": provider, "service": service, "region": region}
 	ciCli.logger.Info("retrieving zones", tags)
 
-	r, _, err := ciCli.RegionApi.GetRegion(context.Background(), provider, service, region)
+	ctx, cancel := ciCli.withTimeout(ctx)
+	defer cancel()
+
+	r, resp, err := ciCli.RegionApi.GetRegion(ctx, provider, service, region)
 	if err != nil {
 		ciCli.logger.Error("failed to retrieve zones", tags)
-		return nil, discriminateErrCtx(err)
+		return nil, discriminateErrCtx(resp, err)
 	}
 
 	ciCli.logger.Info("retrieved zones", tags)
@@ -181,48 +295,112 @@ func (ciCli *cloudInfoClient) GetZones(provider, service, region string) ([]stri
 }
 
 // GetRegions gets regions
-func (ciCli *cloudInfoClient) GetRegions(provider, service string) ([]cloudinfo.Region, error) {
+func (ciCli *cloudInfoClient) GetRegions(ctx context.Context, provider, service string) ([]cloudinfo.Region, error) {
 	tags := map[string]interface{}{"provider": provider, "service": service}
 	ciCli.logger.Info("retrieving regions", tags)
 
-	r, _, err := ciCli.RegionsApi.GetRegions(context.Background(), provider, service)
+	ctx, cancel := ciCli.withTimeout(ctx)
+	defer cancel()
+
+	r, resp, err := ciCli.RegionsApi.GetRegions(ctx, provider, service)
 	if err != nil {
 		ciCli.logger.Error("failed to retrieve regions", tags)
-		return nil, discriminateErrCtx(err)
+		return nil, discriminateErrCtx(resp, err)
 	}
 
 	ciCli.logger.Info("retrieved regions", tags)
 	return r, nil
 }
 
-func (ciCli *cloudInfoClient) GetContinentsData(provider, service string) ([]cloudinfo.Continent, error) {
+func (ciCli *cloudInfoClient) GetContinentsData(ctx context.Context, provider, service string) ([]cloudinfo.Continent, error) {
 	tags := map[string]interface{}{"provider": provider, "service": service}
 	ciCli.logger.Info("retrieving continent data", tags)
 
-	r, _, err := ciCli.ContinentsApi.GetContinentsData(context.Background(), provider, service)
+	ctx, cancel := ciCli.withTimeout(ctx)
+	defer cancel()
+
+	r, resp, err := ciCli.ContinentsApi.GetContinentsData(ctx, provider, service)
 	if err != nil {
 		ciCli.logger.Error("failed to retrieve continent data", tags)
-		return nil, discriminateErrCtx(err)
+		return nil, discriminateErrCtx(resp, err)
 	}
 
 	ciCli.logger.Info("retrieved continent data", tags)
 	return r, nil
 }
 
+// GetImages gets the images of the region
+func (ciCli *cloudInfoClient) GetImages(ctx context.Context, provider string, service string, region string) ([]cloudinfo.Image, error) {
+	tags := map[string]interface{}{"provider": provider, "service": service, "region": region}
+	ciCli.logger.Info("retrieving images", tags)
+
+	ctx, cancel := ciCli.withTimeout(ctx)
+	defer cancel()
+
+	r, resp, err := ciCli.ImagesApi.GetImages(ctx, provider, service, region, nil)
+	if err != nil {
+		ciCli.logger.Error("failed to retrieve images", tags)
+		return nil, discriminateErrCtx(resp, err)
+	}
+
+	ciCli.logger.Info("retrieved images", tags)
+	return r.Images, nil
+}
+
+// GetVersions gets the Kubernetes versions of the region
+func (ciCli *cloudInfoClient) GetVersions(ctx context.Context, provider string, service string, region string) ([]cloudinfo.LocationVersion, error) {
+	tags := map[string]interface{}{"provider": provider, "service": service, "region": region}
+	ciCli.logger.Info("retrieving versions", tags)
+
+	ctx, cancel := ciCli.withTimeout(ctx)
+	defer cancel()
+
+	r, resp, err := ciCli.VersionsApi.GetVersions(ctx, provider, service, region)
+	if err != nil {
+		ciCli.logger.Error("failed to retrieve versions", tags)
+		return nil, discriminateErrCtx(resp, err)
+	}
+
+	ciCli.logger.Info("retrieved versions", tags)
+	return r.Versions, nil
+}
+
 // GetContinents gets continents
-func (ciCli *cloudInfoClient) GetContinents() ([]string, error) {
+func (ciCli *cloudInfoClient) GetContinents(ctx context.Context) ([]string, error) {
 	ciCli.logger.Info("retrieving continents")
-	c, _, err := ciCli.ContinentsApi.GetContinents(context.Background())
+	ctx, cancel := ciCli.withTimeout(ctx)
+	defer cancel()
+
+	c, resp, err := ciCli.ContinentsApi.GetContinents(ctx)
 	if err != nil {
 		ciCli.logger.Error("failed to retrieve continents")
-		return nil, discriminateErrCtx(err)
+		return nil, discriminateErrCtx(resp, err)
 	}
 	ciCli.logger.Info("retrieved continents")
 	return c, nil
 }
 
+// ContextError returns the context error causing the error if any, the cloud info client reports it wrapped in an url error
+func ContextError(err error) error {
+	cause := errors.Cause(err)
+	if urlErr, ok := cause.(*url.Error); ok {
+		cause = urlErr.Err
+	}
+	if cause == context.Canceled || cause == context.DeadlineExceeded {
+		return cause
+	}
+	return nil
+}
+
+// NotFound returns true if cloud info responded that it doesn't have the requested data
+func NotFound(err error) bool {
+	e, ok := errors.Cause(err).(*runtime.APIError)
+	return ok && e.Code == http.StatusNotFound
+}
+
 // discriminateErrCtx adds tags to the error context in order to classify them later
-func discriminateErrCtx(err error) error {
+func discriminateErrCtx(resp *http.Response, err error) error {
+	err = apiError(resp, err)
 	if _, ok := err.(*runtime.APIError); ok {
 		// the service can be reached
 		return emperror.With(err, cloudInfoService)
@@ -232,3 +410,12 @@ func discriminateErrCtx(err error) error {
 	// probably connectivity error (should it be analized further?!)
 	return emperror.With(err, cloudInfoClientComponent)
 }
+
+// apiError converts the error response of the cloud info service to an API error holding the status code, so the
+// retries and the not found checks can tell the failures apart
+func apiError(resp *http.Response, err error) error {
+	if apiErr, ok := err.(cloudinfo.GenericOpenAPIError); ok && resp != nil {
+		return runtime.NewAPIError(apiErr.Error(), string(apiErr.Body()), resp.StatusCode)
+	}
+	return err
+}
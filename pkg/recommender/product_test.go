@@ -0,0 +1,114 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/goph/emperror"
+	"github.com/goph/logur"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseNetworkGbps(t *testing.T) {
+	tests := []struct {
+		ntwPerf  string
+		expected float64
+	}{
+		{ntwPerf: "25 Gigabit", expected: 25},
+		{ntwPerf: "Up to 10 Gigabit", expected: 10},
+		{ntwPerf: "16 Gbit/s", expected: 16},
+		{ntwPerf: "500 Mbps", expected: 0.5},
+		{ntwPerf: "Moderate", expected: 0},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.ntwPerf, func(t *testing.T) {
+			assert.Equal(t, test.expected, parseNetworkGbps(test.ntwPerf))
+		})
+	}
+}
+
+func Test_parseLocalStorage(t *testing.T) {
+	tests := []struct {
+		storage      string
+		expectedSize float64
+		expectedType string
+	}{
+		{storage: "2 x 900 NVMe SSD", expectedSize: 1800, expectedType: StorageNvme},
+		{storage: "1 x 1,920 SSD", expectedSize: 1920, expectedType: StorageSsd},
+		{storage: "24 x 2000 HDD", expectedSize: 48000, expectedType: StorageHdd},
+		{storage: "EBS only", expectedSize: 0, expectedType: ""},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.storage, func(t *testing.T) {
+			size, storageType := parseLocalStorage(map[string]string{storageAttribute: test.storage})
+			assert.Equal(t, test.expectedSize, size)
+			assert.Equal(t, test.expectedType, storageType)
+		})
+	}
+}
+
+func Test_parseWindowsLicensePrice(t *testing.T) {
+	tests := []struct {
+		name          string
+		attributes    map[string]string
+		expectedPrice float64
+	}{
+		{name: "windows price provided", attributes: map[string]string{windowsPriceAttribute: "0.188"}, expectedPrice: 0.092},
+		{name: "windows price missing", attributes: map[string]string{}, expectedPrice: 0},
+		{name: "invalid windows price", attributes: map[string]string{windowsPriceAttribute: "n/a"}, expectedPrice: 0},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			assert.InDelta(t, test.expectedPrice, parseWindowsLicensePrice(test.attributes, 0.096), 1e-9)
+		})
+	}
+}
+
+func TestCloudInfoClient_errorResponses(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+	}{
+		{name: "not found", status: http.StatusNotFound},
+		{name: "service unavailable", status: http.StatusServiceUnavailable},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "failure", test.status)
+			}))
+			defer srv.Close()
+
+			ciCli := NewCloudInfoClient(srv.URL, 0, logur.NewTestLogger())
+			_, err := ciCli.GetRegion(context.Background(), "amazon", "compute", "eu-west-1")
+
+			apiErr, ok := errors.Cause(err).(*runtime.APIError)
+			if assert.True(t, ok, "the error response should be converted to an API error") {
+				assert.Equal(t, test.status, apiErr.Code)
+			}
+			assert.Contains(t, emperror.Context(err), cloudInfoService)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// bytesPerGB is the number of bytes in the GB unit the memory values are expressed in
+	bytesPerGB = 1e9
+	// maxQuantityExponent is the largest accepted decimal exponent of a quantity, the same as the largest suffix
+	maxQuantityExponent = 18
+)
+
+var quantityRegexp = regexp.MustCompile(`^([0-9]+(?:\.[0-9]*)?|\.[0-9]+)((?:[eE][+-]?[0-9]+)|Ki|Mi|Gi|Ti|Pi|Ei|n|u|m|k|M|G|T|P|E)?$`)
+
+// quantitySuffixes holds the multipliers of the Kubernetes quantity suffixes
+var quantitySuffixes = map[string]*big.Rat{
+	"":   big.NewRat(1, 1),
+	"n":  big.NewRat(1, 1e9),
+	"u":  big.NewRat(1, 1e6),
+	"m":  big.NewRat(1, 1e3),
+	"k":  big.NewRat(1e3, 1),
+	"M":  big.NewRat(1e6, 1),
+	"G":  big.NewRat(1e9, 1),
+	"T":  big.NewRat(1e12, 1),
+	"P":  big.NewRat(1e15, 1),
+	"E":  big.NewRat(1e18, 1),
+	"Ki": big.NewRat(1<<10, 1),
+	"Mi": big.NewRat(1<<20, 1),
+	"Gi": big.NewRat(1<<30, 1),
+	"Ti": big.NewRat(1<<40, 1),
+	"Pi": big.NewRat(1<<50, 1),
+	"Ei": big.NewRat(1<<60, 1),
+}
+
+// ParseQuantity parses a Kubernetes resource quantity (eg. 500m, 16Gi, 64G or 1e3) to an exact number
+func ParseQuantity(quantity string) (*big.Rat, error) {
+	parts := quantityRegexp.FindStringSubmatch(quantity)
+	if parts == nil {
+		return nil, errors.Errorf("invalid quantity: %s", quantity)
+	}
+
+	value, ok := new(big.Rat).SetString(parts[1])
+	if !ok {
+		return nil, errors.Errorf("invalid quantity: %s", quantity)
+	}
+
+	if multiplier, ok := quantitySuffixes[parts[2]]; ok {
+		return value.Mul(value, multiplier), nil
+	}
+
+	// decimal exponent
+	exp, err := strconv.Atoi(parts[2][1:])
+	if err != nil || abs(exp) > maxQuantityExponent {
+		return nil, errors.Errorf("invalid quantity: %s", quantity)
+	}
+	multiplier := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(exp))), nil))
+	if exp < 0 {
+		multiplier.Inv(multiplier)
+	}
+	return value.Mul(value, multiplier), nil
+}
+
+// CpuFromQuantity converts a Kubernetes cpu quantity to the number of cpus
+func CpuFromQuantity(quantity string) (float64, error) {
+	value, err := ParseQuantity(quantity)
+	if err != nil {
+		return 0, err
+	}
+	cpu, _ := value.Float64()
+	return cpu, nil
+}
+
+// MemFromQuantity converts a Kubernetes memory quantity (in bytes) to GB
+func MemFromQuantity(quantity string) (float64, error) {
+	value, err := ParseQuantity(quantity)
+	if err != nil {
+		return 0, err
+	}
+	mem, _ := value.Quo(value, big.NewRat(bytesPerGB, 1)).Float64()
+	return mem, nil
+}
+
+// CpuQuantity formats the number of cpus as a Kubernetes cpu quantity
+func CpuQuantity(cpu float64) string {
+	millis := int64(cpu*1000 + 0.5)
+	if millis%1000 == 0 {
+		return strconv.FormatInt(millis/1000, 10)
+	}
+	return fmt.Sprintf("%dm", millis)
+}
+
+// MemQuantity formats the memory (GB) as a Kubernetes memory quantity, using the largest suffix that keeps the value an integer
+func MemQuantity(mem float64) string {
+	bytes := int64(mem*bytesPerGB + 0.5)
+	if bytes == 0 {
+		return "0"
+	}
+	for _, suffix := range []string{"Ei", "Pi", "Ti", "Gi", "E", "P", "T", "G", "Mi", "M", "Ki", "k"} {
+		multiplier := quantitySuffixes[suffix].Num().Int64()
+		if bytes%multiplier == 0 {
+			return fmt.Sprintf("%d%s", bytes/multiplier, suffix)
+		}
+	}
+	return strconv.FormatInt(bytes, 10)
+}
+
+// ResolveQuantities sets the requested cpu and memory from their quantities and checks that both are requested
+func (r *ClusterRecommendationReq) ResolveQuantities() error {
+	var err error
+	if r.SumCpu, err = resolveQuantity(r.SumCpu, r.CpuQuantity, CpuFromQuantity, "sumCpu", "cpuQuantity"); err != nil {
+		return err
+	}
+	r.SumMem, err = resolveQuantity(r.SumMem, r.MemQuantity, MemFromQuantity, "sumMem", "memQuantity")
+	return err
+}
+
+// ResolveQuantities sets the desired cpu and memory from their quantities and checks that both are requested
+func (r *ClusterScaleoutRecommendationReq) ResolveQuantities() error {
+	var err error
+	if r.DesiredCpu, err = resolveQuantity(r.DesiredCpu, r.DesiredCpuQuantity, CpuFromQuantity, "desiredCpu", "desiredCpuQuantity"); err != nil {
+		return err
+	}
+	r.DesiredMem, err = resolveQuantity(r.DesiredMem, r.DesiredMemQuantity, MemFromQuantity, "desiredMem", "desiredMemQuantity")
+	return err
+}
+
+// ResolveQuantities sets the requested cpu and memory from their quantities; they are optional as they default to the
+// resources of the actual layout
+func (r *ClusterSavingsReq) ResolveQuantities() error {
+	var err error
+	if r.SumCpu, err = resolveOptionalQuantity(r.SumCpu, r.CpuQuantity, CpuFromQuantity, "sumCpu", "cpuQuantity"); err != nil {
+		return err
+	}
+	r.SumMem, err = resolveOptionalQuantity(r.SumMem, r.MemQuantity, MemFromQuantity, "sumMem", "memQuantity")
+	return err
+}
+
+// resolveOptionalQuantity returns the value of a resource requested either as a number or as a quantity, 0 if it's not requested
+func resolveOptionalQuantity(value float64, quantity string, convert func(string) (float64, error), valueField, quantityField string) (float64, error) {
+	if value == 0 && quantity == "" {
+		return 0, nil
+	}
+	return resolveQuantity(value, quantity, convert, valueField, quantityField)
+}
+
+// resolveQuantity returns the value of a resource requested either as a number or as a quantity
+func resolveQuantity(value float64, quantity string, convert func(string) (float64, error), valueField, quantityField string) (float64, error) {
+	if quantity == "" {
+		if value <= 0 {
+			return 0, errors.Errorf("either %s or %s is required", valueField, quantityField)
+		}
+		return value, nil
+	}
+	if value != 0 {
+		return 0, errors.Errorf("only one of %s and %s can be set", valueField, quantityField)
+	}
+
+	converted, err := convert(quantity)
+	if err != nil {
+		return 0, err
+	}
+	if converted <= 0 {
+		return 0, errors.Errorf("%s must be positive", quantityField)
+	}
+	return converted, nil
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
@@ -0,0 +1,162 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemFromQuantity(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantity string
+		check    func(mem float64, err error)
+	}{
+		{
+			name:     "binary suffix",
+			quantity: "16Gi",
+			check: func(mem float64, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 17.179869184, mem)
+			},
+		},
+		{
+			name:     "decimal suffix",
+			quantity: "64G",
+			check: func(mem float64, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, float64(64), mem)
+			},
+		},
+		{
+			name:     "decimal exponent",
+			quantity: "1.5e9",
+			check: func(mem float64, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 1.5, mem)
+			},
+		},
+		{
+			name:     "invalid quantity",
+			quantity: "16GB",
+			check: func(mem float64, err error) {
+				assert.EqualError(t, err, "invalid quantity: 16GB")
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			test.check(MemFromQuantity(test.quantity))
+		})
+	}
+}
+
+func TestCpuFromQuantity(t *testing.T) {
+	cpu, err := CpuFromQuantity("500m")
+	assert.Nil(t, err, "the error should be nil")
+	assert.Equal(t, 0.5, cpu)
+}
+
+func TestQuantityFormatting(t *testing.T) {
+	assert.Equal(t, "16", CpuQuantity(16))
+	assert.Equal(t, "1500m", CpuQuantity(1.5))
+	assert.Equal(t, "16Gi", MemQuantity(17.179869184))
+	assert.Equal(t, "42G", MemQuantity(42))
+	assert.Equal(t, "1500M", MemQuantity(1.5))
+}
+
+func TestClusterRecommendationReq_ResolveQuantities(t *testing.T) {
+	tests := []struct {
+		name  string
+		req   ClusterRecommendationReq
+		check func(req ClusterRecommendationReq, err error)
+	}{
+		{
+			name: "quantities are converted",
+			req:  ClusterRecommendationReq{CpuQuantity: "2500m", MemQuantity: "8G"},
+			check: func(req ClusterRecommendationReq, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 2.5, req.SumCpu)
+				assert.Equal(t, float64(8), req.SumMem)
+			},
+		},
+		{
+			name: "numeric values are kept",
+			req:  ClusterRecommendationReq{SumCpu: 4, MemQuantity: "8G"},
+			check: func(req ClusterRecommendationReq, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, float64(4), req.SumCpu)
+			},
+		},
+		{
+			name: "both value and quantity",
+			req:  ClusterRecommendationReq{SumCpu: 4, CpuQuantity: "4", SumMem: 8},
+			check: func(req ClusterRecommendationReq, err error) {
+				assert.EqualError(t, err, "only one of sumCpu and cpuQuantity can be set")
+			},
+		},
+		{
+			name: "missing memory",
+			req:  ClusterRecommendationReq{SumCpu: 4},
+			check: func(req ClusterRecommendationReq, err error) {
+				assert.EqualError(t, err, "either sumMem or memQuantity is required")
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			err := test.req.ResolveQuantities()
+			test.check(test.req, err)
+		})
+	}
+}
+
+func TestClusterSavingsReq_ResolveQuantities(t *testing.T) {
+	tests := []struct {
+		name  string
+		req   ClusterSavingsReq
+		check func(req ClusterSavingsReq, err error)
+	}{
+		{
+			name: "resources are optional",
+			req:  ClusterSavingsReq{},
+			check: func(req ClusterSavingsReq, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, float64(0), req.SumCpu)
+				assert.Equal(t, float64(0), req.SumMem)
+			},
+		},
+		{
+			name: "quantities are converted",
+			req:  ClusterSavingsReq{SingleClusterRecommendationReq: SingleClusterRecommendationReq{ClusterRecommendationReq: ClusterRecommendationReq{CpuQuantity: "2500m"}}},
+			check: func(req ClusterSavingsReq, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 2.5, req.SumCpu)
+				assert.Equal(t, float64(0), req.SumMem)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			err := test.req.ResolveQuantities()
+			test.check(test.req, err)
+		})
+	}
+}
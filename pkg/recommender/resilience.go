@@ -0,0 +1,142 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/goph/emperror"
+	"github.com/pkg/errors"
+)
+
+// maxResilienceIterations limits the number of resizing rounds when looking for a resilient layout
+const maxResilienceIterations = 10
+
+// getResilientNodePoolSet recommends node pools for the sizing request and enlarges its resources until
+// the worker pools keep the resources of the original request after any of the requested failures
+func (e *Engine) getResilientNodePoolSet(ctx context.Context, provider string, req, sizingReq SingleClusterRecommendationReq, layoutDesc []NodePoolDesc,
+	allProducts []VirtualMachine, zoneCount int,
+) ([]NodePool, error) {
+	for i := 0; i < maxResilienceIterations; i++ {
+		nodePools, err := e.getCheapestNodePoolSet(ctx, provider, sizingReq, layoutDesc, allProducts)
+		if err != nil {
+			return nil, err
+		}
+
+		var missingCpu, missingMem float64
+		for _, capacity := range postFailureCapacities(req.Resilience, nodePools, zoneCount) {
+			missingCpu = math.Max(missingCpu, req.SumCpu-capacity.Cpu)
+			missingMem = math.Max(missingMem, req.SumMem-capacity.Mem)
+		}
+		if missingCpu <= 0 && missingMem <= 0 {
+			return nodePools, nil
+		}
+
+		e.log.Debug(fmt.Sprintf("node pools are not resilient, missing cpu: %v, missing memory: %v", missingCpu, missingMem))
+		sizingReq.SumCpu += math.Max(missingCpu, 0)
+		sizingReq.SumMem += math.Max(missingMem, 0)
+	}
+
+	return nil, emperror.With(errors.Errorf("could not recommend a cluster resilient to the failures: %v", req.Resilience), RecommenderErrorTag)
+}
+
+// resilienceZoneCount returns the number of zones the cluster spreads to if zone failures are to be survived
+func (e *Engine) resilienceZoneCount(ctx context.Context, provider, service, region string, req SingleClusterRecommendationReq) (int, error) {
+	requested := false
+	for _, failure := range req.Resilience {
+		if failure == ResilienceZone {
+			requested = true
+		}
+	}
+	if !requested {
+		return 0, nil
+	}
+
+	if req.Zone != "" {
+		return 0, emperror.With(errors.New("zone failure resilience requires a multi-zone cluster"), RecommenderErrorTag)
+	}
+
+	zones, err := e.ciSource.GetZones(ctx, provider, service, region)
+	if err != nil {
+		return 0, err
+	}
+	if len(zones) < 2 {
+		return 0, emperror.With(errors.Errorf("zone failure resilience requires multiple zones, region %s has %d", region, len(zones)), RecommenderErrorTag)
+	}
+	return len(zones), nil
+}
+
+// postFailureCapacities calculates the worst case worker resources remaining after each of the failures
+// the node pools are considered to be evenly spread across the zones
+func postFailureCapacities(failures []string, nodePools []NodePool, zoneCount int) []ResilienceCapacity {
+	var (
+		sumCpu, sumMem         float64
+		sumNodes               int
+		maxNodeCpu, maxNodeMem float64
+		maxSpotCpu, maxSpotMem float64
+		maxSpotNodes           int
+		zoneCpu, zoneMem       float64
+		zoneNodes              int
+	)
+	for _, np := range nodePools {
+		if np.Role == Master || np.SumNodes == 0 {
+			continue
+		}
+		sumCpu += np.GetSum(Cpu)
+		sumMem += np.GetSum(Memory)
+		sumNodes += np.SumNodes
+
+		maxNodeCpu = math.Max(maxNodeCpu, np.VmType.Cpus)
+		maxNodeMem = math.Max(maxNodeMem, np.VmType.Mem)
+
+		if np.VmClass == Spot {
+			maxSpotCpu = math.Max(maxSpotCpu, np.GetSum(Cpu))
+			maxSpotMem = math.Max(maxSpotMem, np.GetSum(Memory))
+			if np.SumNodes > maxSpotNodes {
+				maxSpotNodes = np.SumNodes
+			}
+		}
+
+		if zoneCount > 0 {
+			// the zone with the most nodes of the pool
+			nodesInZone := int(math.Ceil(float64(np.SumNodes) / float64(zoneCount)))
+			zoneCpu += float64(nodesInZone) * np.VmType.Cpus
+			zoneMem += float64(nodesInZone) * np.VmType.Mem
+			zoneNodes += nodesInZone
+		}
+	}
+
+	capacities := make([]ResilienceCapacity, 0, len(failures))
+	for _, failure := range failures {
+		capacity := ResilienceCapacity{Failure: failure}
+		switch failure {
+		case ResilienceNode:
+			capacity.Cpu, capacity.Mem, capacity.Nodes = sumCpu-maxNodeCpu, sumMem-maxNodeMem, sumNodes-1
+		case ResilienceZone:
+			capacity.Cpu, capacity.Mem, capacity.Nodes = sumCpu-zoneCpu, sumMem-zoneMem, sumNodes-zoneNodes
+		case ResilienceSpotPool:
+			capacity.Cpu, capacity.Mem, capacity.Nodes = sumCpu-maxSpotCpu, sumMem-maxSpotMem, sumNodes-maxSpotNodes
+		default:
+			continue
+		}
+		if capacity.Nodes < 0 {
+			capacity.Nodes = 0
+		}
+		capacities = append(capacities, capacity)
+	}
+	return capacities
+}
@@ -0,0 +1,112 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goph/logur"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_postFailureCapacities(t *testing.T) {
+	nodePools := []NodePool{
+		{VmType: VirtualMachine{Cpus: 4, Mem: 16}, SumNodes: 3, VmClass: Regular, Role: Worker},
+		{VmType: VirtualMachine{Cpus: 8, Mem: 8}, SumNodes: 2, VmClass: Spot, Role: Worker},
+		{VmType: VirtualMachine{Cpus: 2, Mem: 4}, SumNodes: 4, VmClass: Spot, Role: Worker},
+		{VmType: VirtualMachine{Cpus: 16, Mem: 64}, SumNodes: 1, VmClass: Regular, Role: Master},
+	}
+
+	capacities := postFailureCapacities([]string{ResilienceNode, ResilienceZone, ResilienceSpotPool}, nodePools, 3)
+
+	assert.Equal(t, []ResilienceCapacity{
+		// total: 36 cpu, 80 memory, 9 nodes
+		{Failure: ResilienceNode, Cpu: 28, Mem: 64, Nodes: 8},
+		{Failure: ResilienceZone, Cpu: 20, Mem: 48, Nodes: 5},
+		{Failure: ResilienceSpotPool, Cpu: 20, Mem: 64, Nodes: 5},
+	}, capacities)
+}
+
+func TestEngine_RecommendClusterResilience(t *testing.T) {
+	tests := []struct {
+		name    string
+		np      NodePoolRecommender
+		request SingleClusterRecommendationReq
+		check   func(resp *ClusterRecommendationResp, err error)
+	}{
+		{
+			name: "zone failure resilience requires multiple zones",
+			np:   &dummyNodePools{},
+			request: SingleClusterRecommendationReq{
+				ClusterRecommendationReq: ClusterRecommendationReq{
+					MinNodes:   1,
+					MaxNodes:   1,
+					SumMem:     32,
+					SumCpu:     16,
+					Resilience: []string{ResilienceZone},
+				},
+				Zone: "dummyZone",
+			},
+			check: func(resp *ClusterRecommendationResp, err error) {
+				assert.EqualError(t, err, "zone failure resilience requires a multi-zone cluster")
+			},
+		},
+		{
+			name: "node pools can't be enlarged",
+			np:   &dummyNodePools{},
+			request: SingleClusterRecommendationReq{
+				ClusterRecommendationReq: ClusterRecommendationReq{
+					MinNodes:   1,
+					MaxNodes:   1,
+					SumMem:     32,
+					SumCpu:     16,
+					Resilience: []string{ResilienceNode},
+				},
+			},
+			check: func(resp *ClusterRecommendationResp, err error) {
+				assert.EqualError(t, err, "could not recommend a cluster resilient to the failures: [node]")
+			},
+		},
+		{
+			name: "the headroom is not required to survive the failures",
+			np:   &sizedNodePools{},
+			request: SingleClusterRecommendationReq{
+				ClusterRecommendationReq: ClusterRecommendationReq{
+					MinNodes:    1,
+					MaxNodes:    3,
+					SumMem:      32,
+					SumCpu:      16,
+					HeadroomPct: 100,
+					Resilience:  []string{ResilienceNode},
+				},
+			},
+			check: func(resp *ClusterRecommendationResp, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, float64(32), resp.Accuracy.RecCpu)
+				assert.Equal(t, []ResilienceCapacity{{Failure: ResilienceNode, Cpu: 16, Mem: 42, Nodes: 1}}, resp.Accuracy.RecResilience)
+				assert.Equal(t, float64(0), resp.Accuracy.RecHeadroomPrice)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			engine := NewEngine(logur.NewTestLogger(), &dummyProducts{}, &dummyVms{}, test.np)
+
+			test.check(engine.RecommendCluster(context.Background(), "dummyProvider", "dummyService", "dummyRegion", test.request, nil))
+		})
+	}
+}
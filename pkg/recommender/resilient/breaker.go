@@ -0,0 +1,100 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resilient
+
+import (
+	"sync"
+	"time"
+)
+
+// state of a circuit breaker, the values are exposed as the breaker state metric
+type state int
+
+const (
+	// the calls pass through
+	closed state = iota
+	// a single trial call passes through to check whether the endpoint recovered
+	halfOpen
+	// the calls fail fast
+	open
+)
+
+// breaker is a circuit breaker of a cloud info endpoint; it opens after a number of consecutive failures and lets a
+// trial call through once the open period is over
+type breaker struct {
+	threshold   int
+	openTimeout time.Duration
+	now         func() time.Time
+
+	mu        sync.Mutex
+	state     state
+	failures  int
+	openUntil time.Time
+	// signals that the trial call of the half open breaker is in progress
+	trial bool
+}
+
+// allow checks whether a call can be made, the state of the breaker is returned as well
+func (b *breaker) allow() (bool, state) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == open && !b.now().Before(b.openUntil) {
+		b.state = halfOpen
+	}
+	switch b.state {
+	case open:
+		return false, b.state
+	case halfOpen:
+		if b.trial {
+			return false, b.state
+		}
+		b.trial = true
+	}
+	return true, b.state
+}
+
+// success records a successful call, it closes the breaker
+func (b *breaker) success() state {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = closed
+	b.failures = 0
+	b.trial = false
+	return b.state
+}
+
+// failure records a failed call, it opens the breaker if the threshold is reached or the trial call failed
+func (b *breaker) failure() state {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == halfOpen || b.failures >= b.threshold {
+		b.state = open
+		b.openUntil = b.now().Add(b.openTimeout)
+	}
+	b.trial = false
+	return b.state
+}
+
+// release ends the trial call without an outcome, eg. if the caller gave up on it
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+}
@@ -0,0 +1,305 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resilient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/banzaicloud/telescopes/.gen/cloudinfo"
+	"github.com/banzaicloud/telescopes/pkg/recommender"
+	"github.com/go-openapi/runtime"
+	"github.com/goph/emperror"
+	"github.com/goph/logur"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CircuitOpenErrTag marks the errors of the calls rejected by an open circuit breaker
+const CircuitOpenErrTag = "cloud-info-circuit-open"
+
+// Config holds the retry and circuit breaker settings of the calls to the cloud info service
+type Config struct {
+	// Number of retries of the calls failing with server or connection errors, disabled if 0
+	Retries int
+	// Wait before the first retry, doubled for each further retry
+	Backoff time.Duration
+	// Upper limit of the wait between retries, unlimited if 0
+	MaxBackoff time.Duration
+	// Number of consecutive failures opening the circuit breaker of an endpoint, disabled if 0
+	BreakerThreshold int
+	// Period the open circuit breaker fails the calls fast before letting a trial call through
+	BreakerTimeout time.Duration
+}
+
+// CloudInfoSource is a CloudInfoSource retrying the failed calls of the wrapped source with backoff; the calls of an
+// endpoint failing repeatedly are rejected by its circuit breaker until the endpoint recovers
+type CloudInfoSource struct {
+	source recommender.CloudInfoSource
+	config Config
+	log    logur.Logger
+	now    func() time.Time
+	sleep  func(ctx context.Context, d time.Duration) error
+
+	mu       sync.Mutex
+	breakers map[string]*breaker
+
+	retries      *prometheus.CounterVec
+	rejections   *prometheus.CounterVec
+	breakerState *prometheus.GaugeVec
+}
+
+// NewCloudInfoSource wraps the source with retries and circuit breakers
+func NewCloudInfoSource(source recommender.CloudInfoSource, config Config, log logur.Logger) *CloudInfoSource {
+	return &CloudInfoSource{
+		source:   source,
+		config:   config,
+		log:      logur.WithFields(log, map[string]interface{}{"component": "cloud-info-resilience"}),
+		now:      time.Now,
+		sleep:    sleep,
+		breakers: make(map[string]*breaker),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "telescopes",
+			Subsystem: "cloudinfo",
+			Name:      "retries_total",
+			Help:      "Number of retried cloud info calls",
+		}, []string{"endpoint"}),
+		rejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "telescopes",
+			Subsystem: "cloudinfo",
+			Name:      "breaker_rejections_total",
+			Help:      "Number of cloud info calls rejected by an open circuit breaker",
+		}, []string{"endpoint"}),
+		breakerState: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "telescopes",
+			Subsystem: "cloudinfo",
+			Name:      "breaker_state",
+			Help:      "State of the circuit breakers of the cloud info endpoints: 0 closed, 1 half open, 2 open",
+		}, []string{"endpoint"}),
+	}
+}
+
+// Describe implements the prometheus.Collector interface
+func (s *CloudInfoSource) Describe(ch chan<- *prometheus.Desc) {
+	s.retries.Describe(ch)
+	s.rejections.Describe(ch)
+	s.breakerState.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface
+func (s *CloudInfoSource) Collect(ch chan<- prometheus.Metric) {
+	s.retries.Collect(ch)
+	s.rejections.Collect(ch)
+	s.breakerState.Collect(ch)
+}
+
+// GetProductDetails retrieves the product details from the wrapped source
+func (s *CloudInfoSource) GetProductDetails(ctx context.Context, provider string, service string, region string) ([]recommender.VirtualMachine, error) {
+	var vms []recommender.VirtualMachine
+	err := s.call(ctx, "GetProductDetails", func(ctx context.Context) (err error) {
+		vms, err = s.source.GetProductDetails(ctx, provider, service, region)
+		return
+	})
+	return vms, err
+}
+
+// GetRegions retrieves the regions from the wrapped source
+func (s *CloudInfoSource) GetRegions(ctx context.Context, provider, service string) ([]cloudinfo.Region, error) {
+	var regions []cloudinfo.Region
+	err := s.call(ctx, "GetRegions", func(ctx context.Context) (err error) {
+		regions, err = s.source.GetRegions(ctx, provider, service)
+		return
+	})
+	return regions, err
+}
+
+// GetContinentsData retrieves the continents data from the wrapped source
+func (s *CloudInfoSource) GetContinentsData(ctx context.Context, provider, service string) ([]cloudinfo.Continent, error) {
+	var continents []cloudinfo.Continent
+	err := s.call(ctx, "GetContinentsData", func(ctx context.Context) (err error) {
+		continents, err = s.source.GetContinentsData(ctx, provider, service)
+		return
+	})
+	return continents, err
+}
+
+// GetZones retrieves the zones from the wrapped source
+func (s *CloudInfoSource) GetZones(ctx context.Context, provider, service, region string) ([]string, error) {
+	var zones []string
+	err := s.call(ctx, "GetZones", func(ctx context.Context) (err error) {
+		zones, err = s.source.GetZones(ctx, provider, service, region)
+		return
+	})
+	return zones, err
+}
+
+// GetContinents retrieves the supported continents from the wrapped source
+func (s *CloudInfoSource) GetContinents(ctx context.Context) ([]string, error) {
+	var continents []string
+	err := s.call(ctx, "GetContinents", func(ctx context.Context) (err error) {
+		continents, err = s.source.GetContinents(ctx)
+		return
+	})
+	return continents, err
+}
+
+// GetRegion retrieves the region from the wrapped source
+func (s *CloudInfoSource) GetRegion(ctx context.Context, provider string, service string, region string) (string, error) {
+	var name string
+	err := s.call(ctx, "GetRegion", func(ctx context.Context) (err error) {
+		name, err = s.source.GetRegion(ctx, provider, service, region)
+		return
+	})
+	return name, err
+}
+
+// GetProvider retrieves the provider from the wrapped source
+func (s *CloudInfoSource) GetProvider(ctx context.Context, provider string) (string, error) {
+	var name string
+	err := s.call(ctx, "GetProvider", func(ctx context.Context) (err error) {
+		name, err = s.source.GetProvider(ctx, provider)
+		return
+	})
+	return name, err
+}
+
+// GetService retrieves the service from the wrapped source
+func (s *CloudInfoSource) GetService(ctx context.Context, provider string, service string) (string, error) {
+	var name string
+	err := s.call(ctx, "GetService", func(ctx context.Context) (err error) {
+		name, err = s.source.GetService(ctx, provider, service)
+		return
+	})
+	return name, err
+}
+
+// GetImages retrieves the images from the wrapped source
+func (s *CloudInfoSource) GetImages(ctx context.Context, provider string, service string, region string) ([]cloudinfo.Image, error) {
+	var images []cloudinfo.Image
+	err := s.call(ctx, "GetImages", func(ctx context.Context) (err error) {
+		images, err = s.source.GetImages(ctx, provider, service, region)
+		return
+	})
+	return images, err
+}
+
+// GetVersions retrieves the Kubernetes versions from the wrapped source
+func (s *CloudInfoSource) GetVersions(ctx context.Context, provider string, service string, region string) ([]cloudinfo.LocationVersion, error) {
+	var versions []cloudinfo.LocationVersion
+	err := s.call(ctx, "GetVersions", func(ctx context.Context) (err error) {
+		versions, err = s.source.GetVersions(ctx, provider, service, region)
+		return
+	})
+	return versions, err
+}
+
+// call makes the call guarded by the circuit breaker of the endpoint, the transient failures are retried with backoff
+func (s *CloudInfoSource) call(ctx context.Context, endpoint string, fn func(ctx context.Context) error) error {
+	b := s.breaker(endpoint)
+
+	for attempt := 0; ; attempt++ {
+		if b != nil {
+			allowed, state := b.allow()
+			s.breakerState.WithLabelValues(endpoint).Set(float64(state))
+			if !allowed {
+				s.rejections.WithLabelValues(endpoint).Inc()
+				return emperror.With(errors.Errorf("circuit breaker of cloud info endpoint %s is open", endpoint),
+					CircuitOpenErrTag, "endpoint", endpoint)
+			}
+		}
+
+		err := fn(ctx)
+		// the deadline of a single call may expire while the caller is still waiting for the response
+		failed := ctx.Err() == nil && (transient(err) || recommender.ContextError(err) != nil)
+		if b != nil {
+			switch {
+			case failed:
+				s.breakerState.WithLabelValues(endpoint).Set(float64(b.failure()))
+			case err != nil && ctx.Err() != nil:
+				// the caller gave up, it says nothing about the endpoint
+				b.release()
+			default:
+				s.breakerState.WithLabelValues(endpoint).Set(float64(b.success()))
+			}
+		}
+		if !failed || attempt >= s.config.Retries {
+			return err
+		}
+
+		backoff := s.backoff(attempt)
+		s.log.Warn("cloud info call failed, retrying", map[string]interface{}{
+			"endpoint": endpoint, "attempt": attempt + 1, "backoff": backoff.String(), "error": err.Error(),
+		})
+		s.retries.WithLabelValues(endpoint).Inc()
+		if s.sleep(ctx, backoff) != nil {
+			return err
+		}
+	}
+}
+
+// breaker returns the circuit breaker of the endpoint, nil if circuit breaking is disabled
+func (s *CloudInfoSource) breaker(endpoint string) *breaker {
+	if s.config.BreakerThreshold <= 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.breakers[endpoint]
+	if !ok {
+		b = &breaker{threshold: s.config.BreakerThreshold, openTimeout: s.config.BreakerTimeout, now: s.now}
+		s.breakers[endpoint] = b
+	}
+	return b
+}
+
+// backoff returns the wait before the retry following the attempt
+func (s *CloudInfoSource) backoff(attempt int) time.Duration {
+	backoff := s.config.Backoff << uint(attempt)
+	if s.config.MaxBackoff > 0 && (backoff > s.config.MaxBackoff || backoff <= 0) {
+		return s.config.MaxBackoff
+	}
+	return backoff
+}
+
+// transient returns true if the call failed with a server or connection error, that may succeed if retried
+func transient(err error) bool {
+	if err == nil || recommender.ContextError(err) != nil {
+		return false
+	}
+	switch e := errors.Cause(err).(type) {
+	case *runtime.APIError:
+		return e.Code >= http.StatusInternalServerError || e.Code == http.StatusTooManyRequests
+	case *url.Error:
+		return true
+	default:
+		return false
+	}
+}
+
+// sleep waits for the duration or until the context is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resilient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/banzaicloud/telescopes/.gen/cloudinfo"
+	"github.com/banzaicloud/telescopes/pkg/recommender"
+	"github.com/go-openapi/runtime"
+	"github.com/goph/emperror"
+	"github.com/goph/logur"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// failingSource fails the calls with the listed errors, then succeeds
+type failingSource struct {
+	calls int
+	errs  []error
+}
+
+func (s *failingSource) call() error {
+	s.calls++
+	if len(s.errs) == 0 {
+		return nil
+	}
+	err := s.errs[0]
+	s.errs = s.errs[1:]
+	return err
+}
+
+func (s *failingSource) GetProductDetails(ctx context.Context, provider string, service string, region string) ([]recommender.VirtualMachine, error) {
+	return []recommender.VirtualMachine{{Type: "m5.large"}}, s.call()
+}
+
+func (s *failingSource) GetRegions(ctx context.Context, provider, service string) ([]cloudinfo.Region, error) {
+	return nil, s.call()
+}
+
+func (s *failingSource) GetContinentsData(ctx context.Context, provider, service string) ([]cloudinfo.Continent, error) {
+	return nil, s.call()
+}
+
+func (s *failingSource) GetZones(ctx context.Context, provider, service, region string) ([]string, error) {
+	return []string{"eu-west-1a"}, s.call()
+}
+
+func (s *failingSource) GetContinents(ctx context.Context) ([]string, error) {
+	return nil, s.call()
+}
+
+func (s *failingSource) GetRegion(ctx context.Context, provider string, service string, region string) (string, error) {
+	return region, s.call()
+}
+
+func (s *failingSource) GetProvider(ctx context.Context, provider string) (string, error) {
+	return provider, s.call()
+}
+
+func (s *failingSource) GetService(ctx context.Context, provider string, service string) (string, error) {
+	return service, s.call()
+}
+
+func (s *failingSource) GetImages(ctx context.Context, provider string, service string, region string) ([]cloudinfo.Image, error) {
+	return nil, s.call()
+}
+
+func (s *failingSource) GetVersions(ctx context.Context, provider string, service string, region string) ([]cloudinfo.LocationVersion, error) {
+	return nil, s.call()
+}
+
+func serverError() error {
+	return runtime.NewAPIError("internal server error", nil, http.StatusInternalServerError)
+}
+
+func TestCloudInfoSource_retries(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		errs   []error
+		check  func(vms []recommender.VirtualMachine, err error, source *failingSource, backoffs []time.Duration)
+	}{
+		{
+			name:   "server errors are retried with backoff",
+			config: Config{Retries: 3, Backoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond},
+			errs:   []error{serverError(), &url.Error{Op: "Get", Err: errors.New("connection reset")}, serverError()},
+			check: func(vms []recommender.VirtualMachine, err error, source *failingSource, backoffs []time.Duration) {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, len(vms))
+				assert.Equal(t, 4, source.calls)
+				assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond}, backoffs)
+			},
+		},
+		{
+			name:   "retries are exhausted",
+			config: Config{Retries: 1, Backoff: time.Millisecond},
+			errs:   []error{serverError(), serverError(), serverError()},
+			check: func(vms []recommender.VirtualMachine, err error, source *failingSource, backoffs []time.Duration) {
+				assert.Error(t, err)
+				assert.Equal(t, 2, source.calls)
+			},
+		},
+		{
+			name:   "client errors are not retried",
+			config: Config{Retries: 3, Backoff: time.Millisecond},
+			errs:   []error{runtime.NewAPIError("not found", nil, http.StatusNotFound)},
+			check: func(vms []recommender.VirtualMachine, err error, source *failingSource, backoffs []time.Duration) {
+				assert.Error(t, err)
+				assert.Equal(t, 1, source.calls)
+				assert.Empty(t, backoffs)
+			},
+		},
+		{
+			name:   "call timeouts are retried",
+			config: Config{Retries: 1, Backoff: time.Millisecond},
+			errs:   []error{&url.Error{Op: "Get", Err: context.DeadlineExceeded}},
+			check: func(vms []recommender.VirtualMachine, err error, source *failingSource, backoffs []time.Duration) {
+				assert.NoError(t, err)
+				assert.Equal(t, 2, source.calls)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test // scopelint
+		t.Run(test.name, func(t *testing.T) {
+			source := &failingSource{errs: test.errs}
+			var backoffs []time.Duration
+			s := NewCloudInfoSource(source, test.config, logur.NewTestLogger())
+			s.sleep = func(ctx context.Context, d time.Duration) error {
+				backoffs = append(backoffs, d)
+				return nil
+			}
+
+			vms, err := s.GetProductDetails(context.Background(), "amazon", "compute", "eu-west-1")
+			test.check(vms, err, source, backoffs)
+		})
+	}
+}
+
+func TestCloudInfoSource_cancelledRetries(t *testing.T) {
+	source := &failingSource{errs: []error{serverError(), serverError()}}
+	s := NewCloudInfoSource(source, Config{Retries: 3, Backoff: time.Hour}, logur.NewTestLogger())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := s.GetZones(ctx, "amazon", "compute", "eu-west-1")
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, source.calls)
+}
+
+func TestCloudInfoSource_breaker(t *testing.T) {
+	clock := time.Date(2019, 4, 1, 12, 0, 0, 0, time.UTC)
+	source := &failingSource{errs: []error{serverError(), serverError(), serverError()}}
+	s := NewCloudInfoSource(source, Config{BreakerThreshold: 2, BreakerTimeout: time.Minute}, logur.NewTestLogger())
+	s.now = func() time.Time { return clock }
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		_, err := s.GetRegion(ctx, "amazon", "compute", "eu-west-1")
+		assert.Error(t, err)
+	}
+
+	// the breaker is open, the calls fail fast
+	_, err := s.GetRegion(ctx, "amazon", "compute", "eu-west-1")
+	assert.Contains(t, emperror.Context(err), CircuitOpenErrTag)
+	assert.Equal(t, 2, source.calls)
+
+	// the breakers are per endpoint
+	_, err = s.GetProvider(ctx, "amazon")
+	assert.Error(t, err)
+	assert.NotContains(t, emperror.Context(err), CircuitOpenErrTag)
+	assert.Equal(t, 3, source.calls)
+
+	// the trial call succeeds and closes the breaker
+	clock = clock.Add(time.Minute)
+	region, err := s.GetRegion(ctx, "amazon", "compute", "eu-west-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "eu-west-1", region)
+	_, err = s.GetRegion(ctx, "amazon", "compute", "eu-west-1")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, source.calls)
+}
+
+func TestCloudInfoSource_unavailableService(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ciCli := recommender.NewCloudInfoClient(srv.URL, 0, logur.NewTestLogger())
+	s := NewCloudInfoSource(ciCli, Config{Retries: 2, Backoff: time.Millisecond, BreakerThreshold: 3, BreakerTimeout: time.Minute},
+		logur.NewTestLogger())
+
+	ctx := context.Background()
+	_, err := s.GetRegion(ctx, "amazon", "compute", "eu-west-1")
+	assert.Error(t, err)
+	assert.NotContains(t, emperror.Context(err), CircuitOpenErrTag)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests), "the call should be retried")
+
+	// the retries exhausted the threshold, the breaker is open
+	_, err = s.GetRegion(ctx, "amazon", "compute", "eu-west-1")
+	assert.Contains(t, emperror.Context(err), CircuitOpenErrTag)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestBreaker(t *testing.T) {
+	clock := time.Date(2019, 4, 1, 12, 0, 0, 0, time.UTC)
+	b := &breaker{threshold: 1, openTimeout: time.Minute, now: func() time.Time { return clock }}
+
+	allowed, st := b.allow()
+	assert.True(t, allowed)
+	assert.Equal(t, closed, st)
+	assert.Equal(t, open, b.failure())
+
+	allowed, _ = b.allow()
+	assert.False(t, allowed)
+
+	clock = clock.Add(time.Minute)
+	allowed, st = b.allow()
+	assert.True(t, allowed)
+	assert.Equal(t, halfOpen, st)
+	// a single trial call is let through
+	allowed, _ = b.allow()
+	assert.False(t, allowed)
+
+	// the failed trial opens the breaker again
+	assert.Equal(t, open, b.failure())
+	allowed, _ = b.allow()
+	assert.False(t, allowed)
+}
@@ -0,0 +1,342 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/banzaicloud/telescopes/.gen/cloudinfo"
+	"github.com/banzaicloud/telescopes/pkg/recommender"
+	"github.com/goph/emperror"
+	"github.com/goph/logur"
+	"github.com/pkg/errors"
+)
+
+const (
+	// FormatVersion is the version of the snapshot layout
+	FormatVersion = 1
+	// ManifestFile is the name of the snapshot manifest in the snapshot directory
+	ManifestFile = "manifest.json"
+)
+
+// Manifest describes a snapshot and holds the checksums of its files
+type Manifest struct {
+	// Version of the snapshot layout
+	FormatVersion int `json:"formatVersion"`
+	// Version of telescopes creating the snapshot
+	CreatedBy string `json:"createdBy"`
+	// Time of creating the snapshot
+	CreatedAt time.Time `json:"createdAt"`
+	// Address of the cloud info service the snapshot is taken from
+	Source string `json:"source"`
+	// Filters of the snapshot
+	Filter Filter `json:"filter"`
+	// SHA-256 checksums of the snapshot files by their paths relative to the snapshot directory
+	Files map[string]string `json:"files"`
+}
+
+// Filter restricts the snapshot to the listed providers, services and regions, an empty list matches everything
+type Filter struct {
+	Providers []string `json:"providers,omitempty"`
+	Services  []string `json:"services,omitempty"`
+	Regions   []string `json:"regions,omitempty"`
+}
+
+func (f Filter) match(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Exporter walks the cloud info API and writes its responses to a snapshot directory
+type Exporter struct {
+	client  *cloudinfo.APIClient
+	source  string
+	version string
+	log     logur.Logger
+}
+
+// NewExporter creates an exporter of the cloud info service at the address
+func NewExporter(address string, version string, log logur.Logger) *Exporter {
+	return &Exporter{
+		client: cloudinfo.NewAPIClient(&cloudinfo.Configuration{
+			BasePath:      address,
+			DefaultHeader: make(map[string]string),
+			UserAgent:     "Telescopes/go",
+		}),
+		source:  address,
+		version: version,
+		log:     log,
+	}
+}
+
+// Export writes the snapshot of the cloud info data matching the filter to the directory; images and versions are
+// optional, the services not providing them are exported without them. The directory must be empty or missing,
+// so that no files of an earlier snapshot are mixed into the new one
+func (e *Exporter) Export(dir string, filter Filter) (*Manifest, error) {
+	if err := checkEmptyDir(dir); err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	w := &writer{dir: dir, files: make(map[string]string)}
+
+	continents, _, err := e.client.ContinentsApi.GetContinents(ctx)
+	if err != nil {
+		return nil, emperror.Wrap(err, "failed to retrieve continents")
+	}
+	if err := w.write(continents, recommender.SnapshotContinentsFile); err != nil {
+		return nil, err
+	}
+
+	providers, _, err := e.client.ProvidersApi.GetProviders(ctx)
+	if err != nil {
+		return nil, emperror.Wrap(err, "failed to retrieve providers")
+	}
+
+	var exported cloudinfo.ProvidersResponse
+	for _, provider := range providers.Providers {
+		if !filter.match(filter.Providers, provider.Provider) {
+			continue
+		}
+		services, _, err := e.client.ServicesApi.GetServices(ctx, provider.Provider)
+		if err != nil {
+			return nil, emperror.With(emperror.Wrap(err, "failed to retrieve services"), "provider", provider.Provider)
+		}
+
+		p := cloudinfo.Provider{Provider: provider.Provider}
+		for _, service := range services.Services {
+			if !filter.match(filter.Services, service.Service) {
+				continue
+			}
+			if err := e.exportService(ctx, w, provider.Provider, service.Service, filter); err != nil {
+				return nil, err
+			}
+			p.Services = append(p.Services, service)
+		}
+		if len(p.Services) == 0 {
+			e.log.Info("no services of the provider are exported", map[string]interface{}{"provider": provider.Provider})
+			continue
+		}
+		exported.Providers = append(exported.Providers, p)
+	}
+	if err := w.write(exported, recommender.SnapshotProvidersFile); err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{
+		FormatVersion: FormatVersion,
+		CreatedBy:     e.version,
+		CreatedAt:     time.Now().UTC(),
+		Source:        e.source,
+		Filter:        filter,
+		Files:         w.files,
+	}
+	if err := writeJSON(filepath.Join(dir, ManifestFile), manifest); err != nil {
+		return nil, err
+	}
+
+	e.log.Info("exported cloud info snapshot", map[string]interface{}{"dir": dir, "files": len(w.files)})
+	return manifest, nil
+}
+
+// exportService writes the regions of the service and the zones, products, images and versions of its regions
+func (e *Exporter) exportService(ctx context.Context, w *writer, provider, service string, filter Filter) error {
+	tags := map[string]interface{}{"provider": provider, "service": service}
+	e.log.Info("exporting service", tags)
+
+	regions, _, err := e.client.RegionsApi.GetRegions(ctx, provider, service)
+	if err != nil {
+		return emperror.With(emperror.Wrap(err, "failed to retrieve regions"), "provider", provider, "service", service)
+	}
+	continents, _, err := e.client.ContinentsApi.GetContinentsData(ctx, provider, service)
+	if err != nil {
+		return emperror.With(emperror.Wrap(err, "failed to retrieve continents data"), "provider", provider, "service", service)
+	}
+
+	var exported []cloudinfo.Region
+	for _, region := range regions {
+		if !filter.match(filter.Regions, region.Id) {
+			continue
+		}
+		if err := e.exportRegion(ctx, w, provider, service, region.Id); err != nil {
+			return emperror.With(err, "provider", provider, "service", service, "region", region.Id)
+		}
+		exported = append(exported, region)
+	}
+
+	if err := w.write(exported, provider, service, recommender.SnapshotRegionsFile); err != nil {
+		return err
+	}
+	return w.write(filteredContinents(continents, exported), provider, service, recommender.SnapshotContinentsDataFile)
+}
+
+// exportRegion writes the zones, products, images and versions of the region
+func (e *Exporter) exportRegion(ctx context.Context, w *writer, provider, service, region string) error {
+	r, _, err := e.client.RegionApi.GetRegion(ctx, provider, service, region)
+	if err != nil {
+		return emperror.Wrap(err, "failed to retrieve region")
+	}
+	if err := w.write(r, provider, service, region, recommender.SnapshotRegionFile); err != nil {
+		return err
+	}
+
+	products, _, err := e.client.ProductsApi.GetProducts(ctx, provider, service, region)
+	if err != nil {
+		return emperror.Wrap(err, "failed to retrieve products")
+	}
+	if err := w.write(products, provider, service, region, recommender.SnapshotProductsFile); err != nil {
+		return err
+	}
+
+	tags := map[string]interface{}{"provider": provider, "service": service, "region": region}
+	if images, _, err := e.client.ImagesApi.GetImages(ctx, provider, service, region, nil); err != nil {
+		e.log.Warn(fmt.Sprintf("images are not exported: %s", err), tags)
+	} else if err := w.write(images, provider, service, region, recommender.SnapshotImagesFile); err != nil {
+		return err
+	}
+	if versions, _, err := e.client.VersionsApi.GetVersions(ctx, provider, service, region); err != nil {
+		e.log.Warn(fmt.Sprintf("versions are not exported: %s", err), tags)
+	} else if err := w.write(versions, provider, service, region, recommender.SnapshotVersionsFile); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// filteredContinents returns the continents with the exported regions only
+func filteredContinents(continents []cloudinfo.Continent, regions []cloudinfo.Region) []cloudinfo.Continent {
+	exported := make(map[string]bool, len(regions))
+	for _, r := range regions {
+		exported[r.Id] = true
+	}
+
+	var filtered []cloudinfo.Continent
+	for _, c := range continents {
+		continent := cloudinfo.Continent{Name: c.Name}
+		for _, r := range c.Regions {
+			if exported[r.Id] {
+				continent.Regions = append(continent.Regions, r)
+			}
+		}
+		if len(continent.Regions) > 0 {
+			filtered = append(filtered, continent)
+		}
+	}
+	return filtered
+}
+
+// Verify checks the snapshot files against the checksums of the manifest in the directory
+func Verify(dir string) (*Manifest, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, emperror.Wrap(err, "failed to read snapshot manifest")
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, emperror.Wrap(err, "failed to decode snapshot manifest")
+	}
+	if manifest.FormatVersion != FormatVersion {
+		return nil, errors.Errorf("unsupported snapshot format version %d", manifest.FormatVersion)
+	}
+
+	paths := make([]string, 0, len(manifest.Files))
+	for path := range manifest.Files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+		if err != nil {
+			return nil, emperror.Wrap(err, "failed to read snapshot file")
+		}
+		if checksum(data) != manifest.Files[path] {
+			return nil, errors.Errorf("checksum mismatch of snapshot file %s", path)
+		}
+	}
+	return &manifest, nil
+}
+
+// checkEmptyDir checks that the snapshot directory is empty or doesn't exist yet
+func checkEmptyDir(dir string) error {
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return emperror.With(emperror.Wrap(err, "failed to read snapshot directory"), "dir", dir)
+	}
+	if len(files) > 0 {
+		return errors.Errorf("snapshot directory %s is not empty", dir)
+	}
+	return nil
+}
+
+// writer writes the snapshot files and records their checksums
+type writer struct {
+	dir   string
+	files map[string]string
+}
+
+func (w *writer) write(value interface{}, path ...string) error {
+	rel := filepath.Join(path...) + ".json"
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return emperror.With(emperror.Wrap(err, "failed to encode snapshot file"), "file", rel)
+	}
+	if err := writeFile(filepath.Join(w.dir, rel), data); err != nil {
+		return err
+	}
+	w.files[filepath.ToSlash(rel)] = checksum(data)
+	return nil
+}
+
+func writeJSON(path string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return emperror.With(emperror.Wrap(err, "failed to encode snapshot file"), "file", path)
+	}
+	return writeFile(path, data)
+}
+
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return emperror.With(emperror.Wrap(err, "failed to create snapshot directory"), "dir", filepath.Dir(path))
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return emperror.With(emperror.Wrap(err, "failed to write snapshot file"), "file", path)
+	}
+	return nil
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
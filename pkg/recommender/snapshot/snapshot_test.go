@@ -0,0 +1,116 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/banzaicloud/telescopes/pkg/recommender"
+	"github.com/goph/logur"
+	"github.com/stretchr/testify/assert"
+)
+
+// cloudInfoResponses holds the responses of a fake cloud info service by request path
+var cloudInfoResponses = map[string]string{
+	"/continents":                            `["Europe", "North America"]`,
+	"/providers":                             `{"providers": [{"provider": "amazon"}, {"provider": "google"}]}`,
+	"/providers/amazon/services":             `{"services": [{"service": "compute"}, {"service": "eks"}]}`,
+	"/providers/google/services":             `{"services": [{"service": "gke"}]}`,
+	"/providers/amazon/services/eks/regions": `[{"id": "eu-west-1", "name": "EU (Ireland)"}, {"id": "us-east-1", "name": "US East (N. Virginia)"}]`,
+	"/providers/amazon/services/eks/continents": `[{"name": "Europe", "regions": [{"id": "eu-west-1", "name": "EU (Ireland)"}]},
+		{"name": "North America", "regions": [{"id": "us-east-1", "name": "US East (N. Virginia)"}]}]`,
+	"/providers/amazon/services/eks/regions/eu-west-1":          `{"id": "eu-west-1", "name": "EU (Ireland)", "zones": ["eu-west-1a", "eu-west-1b"]}`,
+	"/providers/amazon/services/eks/regions/eu-west-1/products": `{"products": [{"type": "m5.large", "cpusPerVm": 2, "memPerVm": 8, "onDemandPrice": 0.107}]}`,
+	"/providers/amazon/services/eks/regions/eu-west-1/images":   `{"images": [{"name": "ami-0123456789", "version": "1.12"}]}`,
+}
+
+func newCloudInfoServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, ok := cloudInfoResponses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(resp))
+	}))
+}
+
+func TestExporter_Export(t *testing.T) {
+	server := newCloudInfoServer()
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	filter := Filter{Providers: []string{"amazon"}, Services: []string{"eks"}, Regions: []string{"eu-west-1"}}
+	manifest, err := NewExporter(server.URL, "0.1.0", logur.NewTestLogger()).Export(dir, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, FormatVersion, manifest.FormatVersion)
+	assert.Contains(t, manifest.Files, "amazon/eks/eu-west-1/images.json")
+	assert.NotContains(t, manifest.Files, "amazon/eks/eu-west-1/versions.json", "missing versions should be skipped")
+
+	verified, err := Verify(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, manifest.Files, verified.Files)
+
+	source, err := recommender.NewFileCloudInfoSource(dir, logur.NewTestLogger())
+	assert.NoError(t, err)
+	_, err = source.GetService(context.Background(), "amazon", "compute")
+	assert.Error(t, err, "filtered services should not be exported")
+	zones, err := source.GetZones(context.Background(), "amazon", "eks", "eu-west-1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"eu-west-1a", "eu-west-1b"}, zones)
+	vms, err := source.GetProductDetails(context.Background(), "amazon", "eks", "eu-west-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "m5.large", vms[0].Type)
+	continents, err := source.GetContinentsData(context.Background(), "amazon", "eks")
+	assert.NoError(t, err)
+	assert.Len(t, continents, 1)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "amazon", "eks", "regions.json"), []byte(`[]`), 0644))
+	_, err = Verify(dir)
+	assert.EqualError(t, err, "checksum mismatch of snapshot file amazon/eks/regions.json")
+}
+
+func TestExporter_ExportFilteredProviders(t *testing.T) {
+	server := newCloudInfoServer()
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	exporter := NewExporter(server.URL, "0.1.0", logur.NewTestLogger())
+	_, err = exporter.Export(dir, Filter{Services: []string{"eks"}, Regions: []string{"eu-west-1"}})
+	assert.NoError(t, err)
+
+	source, err := recommender.NewFileCloudInfoSource(dir, logur.NewTestLogger())
+	assert.NoError(t, err)
+	_, err = source.GetProvider(context.Background(), "amazon")
+	assert.NoError(t, err)
+	_, err = source.GetProvider(context.Background(), "google")
+	assert.Error(t, err, "providers without exported services should be skipped")
+
+	_, err = exporter.Export(dir, Filter{Services: []string{"eks"}, Regions: []string{"eu-west-1"}})
+	assert.EqualError(t, err, "snapshot directory "+dir+" is not empty")
+}
@@ -0,0 +1,101 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spotadvisor
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/goph/emperror"
+	"github.com/pkg/errors"
+)
+
+const (
+	// the spot advisor data only covers amazon instance types
+	amazon = "amazon"
+	// interruption frequencies are taken from the linux instances
+	linux = "Linux"
+)
+
+// advisorData represents the structure of the AWS Spot Advisor data file
+type advisorData struct {
+	Ranges []struct {
+		Index int     `json:"index"`
+		Label string  `json:"label"`
+		Max   float64 `json:"max"`
+	} `json:"ranges"`
+	SpotAdvisor map[string]map[string]map[string]struct {
+		Savings int `json:"s"`
+		Range   int `json:"r"`
+	} `json:"spot_advisor"`
+}
+
+// InterruptionRisks holds the spot interruption frequencies per region and instance type
+type InterruptionRisks struct {
+	// region -> instance type -> upper bound of the interruption frequency range (percentage)
+	risks map[string]map[string]float64
+}
+
+// Load reads the interruption frequencies from the AWS Spot Advisor data file at the given path
+func Load(path string) (*InterruptionRisks, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, emperror.Wrap(err, "failed to open spot advisor data file")
+	}
+	defer f.Close()
+
+	return Parse(f)
+}
+
+// Parse reads the interruption frequencies from AWS Spot Advisor formatted data
+func Parse(r io.Reader) (*InterruptionRisks, error) {
+	var data advisorData
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, emperror.Wrap(err, "failed to decode spot advisor data")
+	}
+
+	ranges := make(map[int]float64, len(data.Ranges))
+	for _, r := range data.Ranges {
+		ranges[r.Index] = r.Max
+	}
+
+	risks := make(map[string]map[string]float64, len(data.SpotAdvisor))
+	for region, osAdvices := range data.SpotAdvisor {
+		advices, ok := osAdvices[linux]
+		if !ok {
+			continue
+		}
+		risks[region] = make(map[string]float64, len(advices))
+		for instanceType, advice := range advices {
+			max, ok := ranges[advice.Range]
+			if !ok {
+				return nil, errors.Errorf("unknown interruption range %d for instance type %s in region %s", advice.Range, instanceType, region)
+			}
+			risks[region][instanceType] = max
+		}
+	}
+
+	return &InterruptionRisks{risks: risks}, nil
+}
+
+// InterruptionRisk returns the interruption frequency of the instance type in the region, false if it's unknown
+func (ir *InterruptionRisks) InterruptionRisk(provider, region, instanceType string) (float64, bool) {
+	if provider != amazon {
+		return 0, false
+	}
+	risk, ok := ir.risks[region][instanceType]
+	return risk, ok
+}
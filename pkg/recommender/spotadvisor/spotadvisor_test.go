@@ -0,0 +1,106 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spotadvisor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const advisorJson = `{
+  "ranges": [
+    {"index": 0, "label": "<5%", "dots": 0, "max": 5},
+    {"index": 1, "label": "5-10%", "dots": 1, "max": 11},
+    {"index": 4, "label": ">20%", "dots": 4, "max": 100}
+  ],
+  "spot_advisor": {
+    "eu-west-1": {
+      "Linux": {
+        "m5.large": {"s": 70, "r": 0},
+        "c5.large": {"s": 60, "r": 4}
+      },
+      "Windows": {
+        "m5.large": {"s": 50, "r": 1}
+      }
+    }
+  }
+}`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		provider     string
+		region       string
+		instanceType string
+		check        func(risk float64, ok bool, err error)
+	}{
+		{
+			name:         "linux interruption frequency found",
+			data:         advisorJson,
+			provider:     "amazon",
+			region:       "eu-west-1",
+			instanceType: "c5.large",
+			check: func(risk float64, ok bool, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.True(t, ok)
+				assert.Equal(t, float64(100), risk)
+			},
+		},
+		{
+			name:         "unknown instance type",
+			data:         advisorJson,
+			provider:     "amazon",
+			region:       "eu-west-1",
+			instanceType: "m4.large",
+			check: func(risk float64, ok bool, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.False(t, ok)
+			},
+		},
+		{
+			name:         "other providers are not covered",
+			data:         advisorJson,
+			provider:     "google",
+			region:       "eu-west-1",
+			instanceType: "m5.large",
+			check: func(risk float64, ok bool, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.False(t, ok)
+			},
+		},
+		{
+			name: "unknown range",
+			data: `{"ranges": [], "spot_advisor": {"eu-west-1": {"Linux": {"m5.large": {"s": 70, "r": 2}}}}}`,
+			check: func(risk float64, ok bool, err error) {
+				assert.EqualError(t, err, "unknown interruption range 2 for instance type m5.large in region eu-west-1")
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			risks, err := Parse(strings.NewReader(test.data))
+			if err != nil {
+				test.check(0, false, err)
+				return
+			}
+			risk, ok := risks.InterruptionRisk(test.provider, test.region, test.instanceType)
+			test.check(risk, ok, nil)
+		})
+	}
+}
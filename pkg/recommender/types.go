@@ -14,7 +14,14 @@
 
 package recommender
 
+import "context"
+
 const (
+	// local disk types
+	StorageNvme = "nvme"
+	StorageSsd  = "ssd"
+	StorageHdd  = "hdd"
+
 	// vm types - regular and ondemand means the same, they are both accepted on the API
 	Regular  = "regular"
 	Ondemand = "ondemand"
@@ -29,28 +36,109 @@ const (
 	Worker = "worker"
 
 	RecommenderErrorTag = "recommender"
+
+	// failures the recommended cluster can be requested to survive
+	ResilienceNode     = "node"
+	ResilienceZone     = "zone"
+	ResilienceSpotPool = "spotPool"
+
+	// migration plan actions
+	MigrationAdd   = "add"
+	MigrationDrain = "drain"
+
+	// interpretations of the on-demand percentage in the recommendation request
+	OnDemandPctResources = "resources"
+	OnDemandPctCost      = "cost"
+	OnDemandPctNodes     = "nodes"
+
+	// WorkloadGroupKey is the label and taint key suggested for the node pools of a workload group
+	WorkloadGroupKey = "workload.banzaicloud.io/group"
+
+	// operating systems of the worker nodes
+	OsLinux   = "linux"
+	OsWindows = "windows"
+
+	// units the cost components are charged per
+	CostPerNode    = "node"
+	CostPerZone    = "zone"
+	CostPerCluster = "cluster"
+
+	// HoursPerMonth is the number of hours used to calculate monthly prices from hourly ones
+	HoursPerMonth = 730
 )
 
 // ClusterRecommender is the main entry point for cluster recommendation
 type ClusterRecommender interface {
 	// RecommendCluster performs recommendation based on the provided arguments
-	RecommendCluster(provider string, service string, region string, req SingleClusterRecommendationReq, layoutDesc []NodePoolDesc) (*ClusterRecommendationResp, error)
+	RecommendCluster(ctx context.Context, provider string, service string, region string, req SingleClusterRecommendationReq, layoutDesc []NodePoolDesc) (*ClusterRecommendationResp, error)
 
 	// RecommendClusterScaleOut performs recommendation for an existing layout's scale out
-	RecommendClusterScaleOut(provider string, service string, region string, req ClusterScaleoutRecommendationReq) (*ClusterRecommendationResp, error)
+	RecommendClusterScaleOut(ctx context.Context, provider string, service string, region string, req ClusterScaleoutRecommendationReq) (*ClusterRecommendationResp, error)
 
 	// RecommendMultiCluster performs recommendations
-	RecommendMultiCluster(req MultiClusterRecommendationReq) (map[string][]*ClusterRecommendationResp, error)
+	RecommendMultiCluster(ctx context.Context, req MultiClusterRecommendationReq) (map[string][]*ClusterRecommendationResp, error)
+
+	// QuoteCluster calculates the price of an existing layout
+	QuoteCluster(ctx context.Context, provider string, service string, region string, req ClusterQuoteReq) (*ClusterRecommendationResp, error)
+
+	// RecommendClusterSavings compares the costs of an existing layout with the recommended one
+	RecommendClusterSavings(ctx context.Context, provider string, service string, region string, req ClusterSavingsReq) (*ClusterSavingsResp, error)
+
+	// RecommendClusterMigration plans the migration of an existing layout to the recommended one
+	RecommendClusterMigration(ctx context.Context, provider string, service string, region string, req ClusterMigrationReq) (*ClusterMigrationResp, error)
+
+	// RecommendClusterWorkloadGroups recommends dedicated node pools for the workload groups of a cluster
+	RecommendClusterWorkloadGroups(ctx context.Context, provider string, service string, region string, req ClusterWorkloadGroupsReq) (*ClusterWorkloadGroupsResp, error)
 }
 
 type VmRecommender interface {
-	RecommendVms(provider string, vms []VirtualMachine, attr string, req SingleClusterRecommendationReq, layout []NodePool) ([]VirtualMachine, []VirtualMachine, error)
+	RecommendVms(ctx context.Context, provider string, vms []VirtualMachine, attr string, req SingleClusterRecommendationReq, layout []NodePool) ([]VirtualMachine, []VirtualMachine, error)
 
-	FindVmsWithAttrValues(attr string, req SingleClusterRecommendationReq, layoutDesc []NodePoolDesc, allProducts []VirtualMachine) ([]VirtualMachine, error)
+	FindVmsWithAttrValues(ctx context.Context, attr string, req SingleClusterRecommendationReq, layoutDesc []NodePoolDesc, allProducts []VirtualMachine) ([]VirtualMachine, error)
 }
 
 type NodePoolRecommender interface {
-	RecommendNodePools(attr string, req SingleClusterRecommendationReq, layout []NodePool, odVms []VirtualMachine, spotVms []VirtualMachine) []NodePool
+	RecommendNodePools(ctx context.Context, attr string, req SingleClusterRecommendationReq, layout []NodePool, odVms []VirtualMachine, spotVms []VirtualMachine) ([]NodePool, error)
+}
+
+// MaxPodsSource provides the maximum number of pods per node of instance types
+type MaxPodsSource interface {
+	// MaxPods returns the maximum number of pods on a node of the instance type, false if it's unknown
+	MaxPods(provider, instanceType string) (int, bool)
+}
+
+// PerformanceFactorSource provides the relative vCPU performance of instance types
+type PerformanceFactorSource interface {
+	// PerformanceFactor returns the performance of the instance type's vCPU relative to a baseline vCPU, false if it's unknown
+	PerformanceFactor(provider, instanceType string) (float64, bool)
+}
+
+// StaleDataSource is implemented by the cloud info sources serving the last known data when cloud info is unavailable
+type StaleDataSource interface {
+	// StaleProductDetails returns true if the served product details of the region are outdated
+	StaleProductDetails(provider string, service string, region string) bool
+}
+
+// CostComponentSource provides the cluster costs besides the instances
+type CostComponentSource interface {
+	// CostComponents returns the cost components of the provider's service
+	CostComponents(provider, service string) []CostComponent
+}
+
+// CostComponent describes a cluster cost besides the instances, eg. root volumes, load balancers or NAT gateways
+type CostComponent struct {
+	// Name of the cost component
+	Name string `json:"name"`
+	// Unit the price is charged per: node, zone or cluster
+	Unit string `json:"unit"`
+	// Hourly price per unit
+	Price float64 `json:"price"`
+}
+
+// InterruptionRiskSource provides spot interruption frequencies of instance types
+type InterruptionRiskSource interface {
+	// InterruptionRisk returns the interruption frequency (percentage) of the instance type in the region, false if it's unknown
+	InterruptionRisk(provider, region, instanceType string) (float64, bool)
 }
 
 // SingleClusterRecommendationReq encapsulates the recommendation input data
@@ -68,10 +156,14 @@ type SingleClusterRecommendationReq struct {
 
 // ClusterRecommendationReq encapsulates the recommendation input data
 type ClusterRecommendationReq struct {
-	// Total number of CPUs requested for the cluster
-	SumCpu float64 `json:"sumCpu" binding:"min=1"`
-	// Total memory requested for the cluster (GB)
-	SumMem float64 `json:"sumMem" binding:"min=1"`
+	// Total number of CPUs requested for the cluster, required unless cpuQuantity is set
+	SumCpu float64 `json:"sumCpu" binding:"omitempty,min=1"`
+	// Total memory requested for the cluster (GB), required unless memQuantity is set
+	SumMem float64 `json:"sumMem" binding:"omitempty,min=1"`
+	// Total CPUs requested for the cluster as a Kubernetes quantity, eg. 500m or 16
+	CpuQuantity string `json:"cpuQuantity,omitempty" binding:"omitempty,quantity"`
+	// Total memory requested for the cluster as a Kubernetes quantity, eg. 16Gi or 64G
+	MemQuantity string `json:"memQuantity,omitempty" binding:"omitempty,quantity"`
 	// Minimum number of nodes in the recommended cluster
 	MinNodes int `json:"minNodes,omitempty" binding:"min=1,ltefield=MaxNodes"`
 	// Maximum number of nodes in the recommended cluster
@@ -80,6 +172,8 @@ type ClusterRecommendationReq struct {
 	SameSize bool `json:"sameSize,omitempty"`
 	// Percentage of regular (on-demand) nodes in the recommended cluster
 	OnDemandPct int `json:"onDemandPct,omitempty" binding:"min=0,max=100"`
+	// Interpretation of the on-demand percentage: share of resources (default), cost or nodes
+	OnDemandPctMode string `json:"onDemandPctMode,omitempty" binding:"omitempty,onDemandPctMode"`
 	// Total number of GPUs requested for the cluster
 	SumGpu int `json:"sumGpu,omitempty"`
 	// Are burst instances allowed in recommendation
@@ -90,6 +184,36 @@ type ClusterRecommendationReq struct {
 	AllowOlderGen *bool `json:"allowOlderGen,omitempty"`
 	// Category specifies the virtual machine category
 	Category []string `json:"category" binding:"omitempty,dive,category"`
+	// Maximum interruption frequency (percentage) of the spot instance types in the recommended cluster, requires interruption frequency data of the region
+	MaxInterruptionRisk *float64 `json:"maxInterruptionRisk,omitempty" binding:"omitempty,min=0,max=100"`
+	// If true, spot instance types are ranked by their interruption frequency first and by their price second
+	RankByInterruptionRisk bool `json:"rankByInterruptionRisk,omitempty"`
+	// Maximum share (percentage) of the spot capacity in a single instance family, spot node pools are spread across families if set
+	MaxSpotFamilyPct int `json:"maxSpotFamilyPct,omitempty" binding:"min=0,max=100"`
+	// Maximum share (percentage) of the spot capacity in a single instance generation, spot node pools are spread across families if set
+	MaxSpotGenerationPct int `json:"maxSpotGenerationPct,omitempty" binding:"min=0,max=100"`
+	// Failures the recommended cluster should survive while keeping the requested resources: node, zone or spotPool
+	Resilience []string `json:"resilience,omitempty" binding:"omitempty,dive,resilience"`
+	// Percentage of resources kept as headroom for bursts on top of the requested ones
+	HeadroomPct int `json:"headroomPct,omitempty" binding:"min=0"`
+	// Number of extra nodes for surging during rolling node upgrades
+	SurgeNodes int `json:"surgeNodes,omitempty" binding:"min=0"`
+	// If true, the cluster is sized and the instance types are ranked by performance-normalized compute units instead of vCPUs
+	NormalizeCpu bool `json:"normalizeCpu,omitempty"`
+	// Minimum network bandwidth (Gbps) of the recommended instance types
+	MinNetworkGbps float64 `json:"minNetworkGbps,omitempty" binding:"min=0"`
+	// Minimum local disk capacity (GB) of the recommended instance types
+	MinLocalStorage float64 `json:"minLocalStorage,omitempty" binding:"min=0"`
+	// Required local disk type of the recommended instance types: ssd (including nvme) or nvme
+	LocalStorageType string `json:"localStorageType,omitempty" binding:"omitempty,eq=ssd|eq=nvme"`
+	// Total number of pods the cluster should be able to host
+	SumPods int `json:"sumPods,omitempty" binding:"min=0"`
+	// Maximum number of pods per node configured for the cluster, overrides the provider's default limit
+	MaxPodsPerNode int `json:"maxPodsPerNode,omitempty" binding:"min=0"`
+	// Operating system of the worker nodes: linux (default) or windows
+	Os string `json:"os,omitempty" binding:"omitempty,eq=linux|eq=windows"`
+	// Kubernetes version of the cluster; the instance types are limited to the ones having a node image of the version
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
 }
 
 // MultiClusterRecommendationReq encapsulates the recommendation input data
@@ -115,14 +239,20 @@ type Provider struct {
 // ClusterScaleoutRecommendationReq encapsulates the recommendation input data
 // swagger:model recommendClusterScaleOutRequest
 type ClusterScaleoutRecommendationReq struct {
-	// Total desired number of CPUs in the cluster after the scale out
-	DesiredCpu float64 `json:"desiredCpu" binding:"min=1"`
-	// Total desired memory (GB) in the cluster after the scale out
-	DesiredMem float64 `json:"desiredMem" binding:"min=1"`
+	// Total desired number of CPUs in the cluster after the scale out, required unless desiredCpuQuantity is set
+	DesiredCpu float64 `json:"desiredCpu" binding:"omitempty,min=1"`
+	// Total desired memory (GB) in the cluster after the scale out, required unless desiredMemQuantity is set
+	DesiredMem float64 `json:"desiredMem" binding:"omitempty,min=1"`
+	// Total desired CPUs in the cluster after the scale out as a Kubernetes quantity, eg. 500m or 16
+	DesiredCpuQuantity string `json:"desiredCpuQuantity,omitempty" binding:"omitempty,quantity"`
+	// Total desired memory in the cluster after the scale out as a Kubernetes quantity, eg. 16Gi or 64G
+	DesiredMemQuantity string `json:"desiredMemQuantity,omitempty" binding:"omitempty,quantity"`
 	// Total desired number of GPUs in the cluster after the scale out
 	DesiredGpu int `json:"desiredGpu" binding:"min=0"`
 	// Percentage of regular (on-demand) nodes among the scale out nodes
 	OnDemandPct int `json:"onDemandPct,omitempty" binding:"min=0,max=100"`
+	// Interpretation of the on-demand percentage: share of resources (default), cost or nodes
+	OnDemandPctMode string `json:"onDemandPctMode,omitempty" binding:"omitempty,onDemandPctMode"`
 	// Availability zone to be included in the recommendation
 	Zone string `json:"zone,omitempty"`
 	// Excludes is a blacklist - a slice with vm types to be excluded from the recommendation
@@ -132,6 +262,63 @@ type ClusterScaleoutRecommendationReq struct {
 	ActualLayout []NodePoolDesc `json:"actualLayout" binding:"required"`
 }
 
+// ClusterQuoteReq encapsulates the data of a cluster layout to be priced
+// swagger:model quoteClusterRequest
+type ClusterQuoteReq struct {
+	// Availability zone of the cluster
+	Zone string `json:"zone,omitempty"`
+	// Operating system of the worker nodes: linux (default) or windows
+	Os string `json:"os,omitempty" binding:"omitempty,eq=linux|eq=windows"`
+	// Description of the cluster layout to be priced
+	// in:body
+	Layout []NodePoolDesc `json:"layout" binding:"required,dive"`
+}
+
+// ClusterWorkloadGroupsReq encapsulates the input data for recommending dedicated node pools for workload groups
+// swagger:model recommendClusterWorkloadGroupsRequest
+type ClusterWorkloadGroupsReq struct {
+	// Availability zone that the cluster should expand to
+	Zone string `json:"zone,omitempty"`
+	// Workload groups of the cluster, each getting its own node pools
+	// in:body
+	Groups []WorkloadGroupReq `json:"groups" binding:"required,min=1,dive"`
+}
+
+// WorkloadGroupReq encapsulates the requirements of a named group of workloads
+type WorkloadGroupReq struct {
+	// Name of the workload group
+	Name string `json:"name" binding:"required"`
+	// If true, the node pools of the group are suggested to be tainted so that only the group's workloads are scheduled on them
+	Dedicated bool `json:"dedicated,omitempty"`
+	// Embedded struct
+	ClusterRecommendationReq
+	// Excludes is a blacklist - a slice with vm types to be excluded from the group's node pools
+	Excludes []string `json:"excludes,omitempty"`
+	// Includes is a whitelist - a slice with vm types to be contained in the group's node pools
+	Includes []string `json:"includes,omitempty"`
+}
+
+// ClusterSavingsReq encapsulates the input data for comparing an existing layout with the recommended one;
+// the requested cpu and memory are optional, the recommendation is sized for the resources of the actual layout
+// swagger:model recommendClusterSavingsRequest
+type ClusterSavingsReq struct {
+	// Embedded struct
+	SingleClusterRecommendationReq
+	// Description of the current cluster layout
+	// in:body
+	ActualLayout []NodePoolDesc `json:"actualLayout" binding:"required,dive"`
+}
+
+// ClusterMigrationReq encapsulates the input data for planning the migration of an existing layout
+// swagger:model recommendClusterMigrationRequest
+type ClusterMigrationReq struct {
+	// Embedded struct
+	SingleClusterRecommendationReq
+	// Description of the current cluster layout
+	// in:body
+	ActualLayout []NodePoolDesc `json:"actualLayout" binding:"required,dive"`
+}
+
 type NodePoolDesc struct {
 	// Instance type of VMs in the node pool
 	InstanceType string `json:"instanceType" binding:"required"`
@@ -168,6 +355,121 @@ type ClusterRecommendationResp struct {
 	NodePools []NodePool `json:"nodePools"`
 	// Accuracy of the recommendation
 	Accuracy ClusterRecommendationAccuracy `json:"accuracy"`
+	// Signals that the recommendation is based on outdated product details as cloud info is unavailable
+	Stale bool `json:"stale,omitempty"`
+	// Kubernetes version of the recommended cluster
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+}
+
+// ClusterSavingsResp encapsulates the cost comparison of the current and the recommended layout
+type ClusterSavingsResp struct {
+	// The current layout with its costs
+	Current ClusterRecommendationResp `json:"current"`
+	// The recommended layout with its costs
+	Recommended ClusterRecommendationResp `json:"recommended"`
+	// Hourly price difference between the current and the recommended layout
+	HourlySavings float64 `json:"hourlySavings"`
+	// Monthly price difference between the current and the recommended layout
+	MonthlySavings float64 `json:"monthlySavings"`
+	// Monthly price of the current layout
+	CurrentMonthlyPrice float64 `json:"currentMonthlyPrice"`
+	// Monthly price of the recommended layout
+	RecommendedMonthlyPrice float64 `json:"recommendedMonthlyPrice"`
+	// Savings in percentage of the current price
+	SavingsPct float64 `json:"savingsPct"`
+	// Node count changes per node pool
+	NodePoolDiff []NodePoolDiff `json:"nodePoolDiff"`
+}
+
+// NodePoolDiff describes the node count change of a node pool between two layouts
+type NodePoolDiff struct {
+	// Instance type of VMs in the node pool
+	InstanceType string `json:"instanceType"`
+	// Signals that the node pool consists of regular or spot/preemptible instance types
+	VmClass string `json:"vmClass"`
+	// Role in the cluster, eg. master or worker
+	Role string `json:"role"`
+	// Number of nodes in the current layout
+	CurrentNodes int `json:"currentNodes"`
+	// Number of nodes in the recommended layout
+	RecommendedNodes int `json:"recommendedNodes"`
+	// Change in the number of nodes
+	Diff int `json:"diff"`
+	// Change in the hourly price of the node pool
+	PriceDiff float64 `json:"priceDiff"`
+}
+
+// ClusterMigrationResp encapsulates the migration plan from the current layout to the recommended one
+type ClusterMigrationResp struct {
+	// The current layout with its costs
+	Current ClusterRecommendationResp `json:"current"`
+	// The recommended target layout with its costs
+	Target ClusterRecommendationResp `json:"target"`
+	// Ordered steps of the migration
+	Steps []MigrationStep `json:"steps"`
+	// Signals whether the target layout is reached at the end of the migration
+	Complete bool `json:"complete"`
+}
+
+// ClusterWorkloadGroupsResp encapsulates the node pools recommended for the workload groups of a cluster
+type ClusterWorkloadGroupsResp struct {
+	// The cloud provider
+	Provider string `json:"provider"`
+	// Provider's service
+	Service string `json:"service"`
+	// Service's region
+	Region string `json:"region"`
+	// Availability zone in the recommendation
+	Zone string `json:"zone,omitempty"`
+	// Node pools recommended for the workload groups
+	Groups []WorkloadGroupNodePools `json:"groups"`
+	// Recommended master node pool, if the service requires one
+	Master *NodePool `json:"master,omitempty"`
+	// Accuracy of the whole cluster
+	Accuracy ClusterRecommendationAccuracy `json:"accuracy"`
+	// Signals that the recommendation is based on outdated product details as cloud info is unavailable
+	Stale bool `json:"stale,omitempty"`
+}
+
+// WorkloadGroupNodePools encapsulates the node pools dedicated to a workload group
+type WorkloadGroupNodePools struct {
+	// Name of the workload group
+	Name string `json:"name"`
+	// Recommended node pools of the group
+	NodePools []NodePool `json:"nodePools"`
+	// Labels suggested for the node pools of the group
+	Labels map[string]string `json:"labels"`
+	// Taints suggested for the node pools of the group
+	Taints []Taint `json:"taints,omitempty"`
+	// Accuracy of the node pools of the group
+	Accuracy ClusterRecommendationAccuracy `json:"accuracy"`
+}
+
+// Taint describes a Kubernetes node taint
+type Taint struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Effect string `json:"effect"`
+}
+
+// MigrationStep describes a single change of a node pool during the migration
+type MigrationStep struct {
+	// Action performed on the node pool, eg. add or drain
+	Action string `json:"action"`
+	// Instance type of VMs in the node pool
+	InstanceType string `json:"instanceType"`
+	// Signals that the node pool consists of regular or spot/preemptible instance types
+	VmClass string `json:"vmClass"`
+	// Number of nodes added to or drained from the node pool
+	Nodes int `json:"nodes"`
+	// Number of nodes in the node pool after the step
+	PoolNodes int `json:"poolNodes"`
+	// Number of cpus in the worker node pools after the step
+	Cpu float64 `json:"cpu"`
+	// Amount of memory in the worker node pools after the step
+	Mem float64 `json:"memory"`
+	// Total price of the cluster after the step
+	TotalPrice float64 `json:"totalPrice"`
 }
 
 // NodePool represents a set of instances with a specific vm type
@@ -180,9 +482,13 @@ type NodePool struct {
 	VmClass string `json:"vmClass"`
 	// Role in the cluster, eg. master or worker
 	Role string `json:"role"`
+	// Operating system of the nodes, linux if empty
+	Os string `json:"os,omitempty"`
+	// Node image of the requested Kubernetes version
+	Image string `json:"image,omitempty"`
 }
 
-// PoolPrice calculates the price of the pool
+// PoolPrice calculates the price of the pool, including the license price of the operating system
 func (n *NodePool) PoolPrice() float64 {
 	sum := float64(0)
 	switch n.VmClass {
@@ -191,6 +497,9 @@ func (n *NodePool) PoolPrice() float64 {
 	case Spot:
 		sum = float64(n.SumNodes) * n.VmType.AvgPrice
 	}
+	if sum > 0 && n.Os == OsWindows {
+		sum += float64(n.SumNodes) * n.VmType.WindowsLicensePrice
+	}
 	return sum
 }
 
@@ -205,6 +514,10 @@ type ClusterRecommendationAccuracy struct {
 	RecMem float64 `json:"memory"`
 	// Number of recommended cpus
 	RecCpu float64 `json:"cpu"`
+	// The summarised amount of memory in the recommended cluster as a Kubernetes quantity
+	RecMemQuantity string `json:"memQuantity"`
+	// Number of recommended cpus as a Kubernetes quantity
+	RecCpuQuantity string `json:"cpuQuantity"`
 	// Number of recommended nodes
 	RecNodes int `json:"nodes"`
 	// Availability zone in the recommendation
@@ -223,6 +536,45 @@ type ClusterRecommendationAccuracy struct {
 	RecMasterPrice float64 `json:"masterPrice"`
 	// Total price in the recommended cluster
 	RecTotalPrice float64 `json:"totalPrice"`
+	// Average interruption frequency (percentage) of the spot nodes with known interruption frequency
+	RecSpotInterruptionRisk float64 `json:"spotInterruptionRisk,omitempty"`
+	// Worker resources remaining after the failures the cluster was requested to survive
+	RecResilience []ResilienceCapacity `json:"resilience,omitempty"`
+	// Price difference between the worker node pools sized with and without the headroom
+	RecHeadroomPrice float64 `json:"headroomPrice,omitempty"`
+	// Amount of surge instance type prices in the recommended cluster
+	RecSurgePrice float64 `json:"surgePrice,omitempty"`
+	// Number of pods the worker nodes can host
+	RecPods int `json:"pods,omitempty"`
+	// Performance-normalized compute units in the recommended cluster
+	RecComputeUnits float64 `json:"computeUnits,omitempty"`
+	// Amount of operating system license prices in the recommended cluster
+	RecLicensePrice float64 `json:"licensePrice,omitempty"`
+	// Prices of the cluster costs besides the instances, included in the total price
+	RecCostComponents []CostComponentPrice `json:"costComponents,omitempty"`
+	// Amount of the cost component prices in the recommended cluster
+	RecCostComponentsPrice float64 `json:"costComponentsPrice,omitempty"`
+}
+
+// CostComponentPrice describes the price of a cost component in the cluster
+type CostComponentPrice struct {
+	CostComponent
+	// Number of units in the cluster
+	Quantity int `json:"quantity"`
+	// Hourly price of the units in the cluster
+	TotalPrice float64 `json:"totalPrice"`
+}
+
+// ResilienceCapacity describes the worst case worker resources remaining after a failure
+type ResilienceCapacity struct {
+	// The failure, eg. node, zone or spotPool
+	Failure string `json:"failure"`
+	// Number of remaining cpus
+	Cpu float64 `json:"cpu"`
+	// Amount of remaining memory
+	Mem float64 `json:"memory"`
+	// Number of remaining nodes
+	Nodes int `json:"nodes"`
 }
 
 // VirtualMachine describes an instance type
@@ -251,6 +603,28 @@ type VirtualMachine struct {
 	NetworkPerf string `json:"networkPerf"`
 	// NetworkPerfCat holds the network performance category
 	NetworkPerfCat string `json:"networkPerfCategory"`
+	// NetworkGbps holds the network bandwidth parsed from the network performance, 0 if it's unknown
+	NetworkGbps float64 `json:"networkGbps,omitempty"`
+	// LocalStorage holds the capacity of the local disks (GB)
+	LocalStorage float64 `json:"localStorage,omitempty"`
+	// LocalStorageType holds the type of the local disks: nvme, ssd or hdd
+	LocalStorageType string `json:"localStorageType,omitempty"`
+	// InterruptionRisk holds the spot interruption frequency (percentage), nil if it's unknown
+	InterruptionRisk *float64 `json:"interruptionRisk,omitempty"`
+	// MaxPods holds the maximum number of pods on a node of the instance type, 0 if it's unknown
+	MaxPods int `json:"maxPods,omitempty"`
+	// PerfFactor holds the performance of the instance type's vCPU relative to a baseline vCPU, 0 if it's unknown
+	PerfFactor float64 `json:"performanceFactor,omitempty"`
+	// WindowsLicensePrice holds the hourly price of the Windows license on the instance type
+	WindowsLicensePrice float64 `json:"windowsLicensePrice,omitempty"`
+}
+
+// ComputeUnits returns the performance-normalized cpu capacity of the instance type, instance types with unknown performance count as baseline
+func (v *VirtualMachine) ComputeUnits() float64 {
+	if v.PerfFactor > 0 {
+		return v.Cpus * v.PerfFactor
+	}
+	return v.Cpus
 }
 
 func (v *VirtualMachine) GetAttrValue(attr string) float64 {
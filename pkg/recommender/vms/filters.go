@@ -15,6 +15,8 @@
 package vms
 
 import (
+	"regexp"
+
 	"github.com/banzaicloud/telescopes/pkg/recommender"
 	"github.com/goph/emperror"
 	"github.com/pkg/errors"
@@ -46,6 +48,14 @@ func (s *vmSelector) filtersForAttr(attr string, provider string, req recommende
 		filters = append(filters, s.ntwPerformanceFilter)
 	}
 
+	if req.MinNetworkGbps > 0 {
+		filters = append(filters, s.ntwBandwidthFilter)
+	}
+
+	if req.MinLocalStorage > 0 || req.LocalStorageType != "" {
+		filters = append(filters, s.localStorageFilter)
+	}
+
 	// provider specific filters
 	switch provider {
 	case "amazon":
@@ -56,6 +66,9 @@ func (s *vmSelector) filtersForAttr(attr string, provider string, req recommende
 		if req.AllowOlderGen == nil || !*req.AllowOlderGen {
 			filters = append(filters, s.currentGenFilter)
 		}
+		if req.Os == recommender.OsWindows {
+			filters = append(filters, s.windowsFilter)
+		}
 	}
 
 	// attribute specific filters
@@ -109,6 +122,27 @@ func (s *vmSelector) ntwPerformanceFilter(vm recommender.VirtualMachine, req rec
 	return s.contains(req.NetworkPerf, vm.NetworkPerfCat)
 }
 
+// ntwBandwidthFilter checks whether the network bandwidth of the vm reaches the requested one, vms with unknown bandwidth don't pass
+func (s *vmSelector) ntwBandwidthFilter(vm recommender.VirtualMachine, req recommender.SingleClusterRecommendationReq) bool {
+	return vm.NetworkGbps >= req.MinNetworkGbps
+}
+
+// localStorageFilter checks whether the local disks of the vm reach the requested capacity and are of the requested type
+func (s *vmSelector) localStorageFilter(vm recommender.VirtualMachine, req recommender.SingleClusterRecommendationReq) bool {
+	if vm.LocalStorage < req.MinLocalStorage || vm.LocalStorage == 0 {
+		return false
+	}
+	switch req.LocalStorageType {
+	case recommender.StorageNvme:
+		return vm.LocalStorageType == recommender.StorageNvme
+	case recommender.StorageSsd:
+		// nvme disks are solid state drives as well
+		return vm.LocalStorageType == recommender.StorageSsd || vm.LocalStorageType == recommender.StorageNvme
+	default:
+		return true
+	}
+}
+
 func (s *vmSelector) categoryFilter(vm recommender.VirtualMachine, req recommender.SingleClusterRecommendationReq) bool {
 	return s.contains(req.Category, vm.Category)
 }
@@ -143,12 +177,33 @@ func (s *vmSelector) filterSpots(vms []recommender.VirtualMachine) []recommender
 	return fvms
 }
 
+// filterInterruptionRisk removes the spot candidates that are interrupted more frequently than the given percentage
+// instance types with unknown interruption frequency are retained
+func (s *vmSelector) filterInterruptionRisk(vms []recommender.VirtualMachine, maxRisk float64) []recommender.VirtualMachine {
+	s.log.Debug("selecting spot instances by interruption frequency", map[string]interface{}{"maxInterruptionRisk": maxRisk})
+	fvms := make([]recommender.VirtualMachine, 0)
+	for _, vm := range vms {
+		if vm.InterruptionRisk == nil || *vm.InterruptionRisk <= maxRisk {
+			fvms = append(fvms, vm)
+		}
+	}
+	return fvms
+}
+
 // currentGenFilter removes instance types that are not the current generation (amazon only)
 func (s *vmSelector) currentGenFilter(vm recommender.VirtualMachine, req recommender.SingleClusterRecommendationReq) bool {
 	// filter by current generation
 	return vm.CurrentGen
 }
 
+// armInstanceTypeRegexp matches the Graviton based instance types (eg. a1.large, m6g.large, c6gd.xlarge)
+var armInstanceTypeRegexp = regexp.MustCompile(`^(a1|[a-z]+[0-9]+g[a-z]*)\.`)
+
+// windowsFilter removes the instance types that can't run Windows nodes (amazon only)
+func (s *vmSelector) windowsFilter(vm recommender.VirtualMachine, req recommender.SingleClusterRecommendationReq) bool {
+	return !armInstanceTypeRegexp.MatchString(vm.Type)
+}
+
 // contains is a helper function to check if a slice contains a string
 func (s *vmSelector) contains(slice []string, str string) bool {
 	for _, e := range slice {
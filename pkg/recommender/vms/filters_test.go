@@ -380,6 +380,38 @@ func TestVmSelector_filterSpots(t *testing.T) {
 	}
 }
 
+func TestVmSelector_filterInterruptionRisk(t *testing.T) {
+	low, high := float64(5), float64(100)
+	tests := []struct {
+		name    string
+		vms     []recommender.VirtualMachine
+		maxRisk float64
+		check   func(filtered []recommender.VirtualMachine)
+	}{
+		{
+			name: "frequently interrupted vm-s filtered out",
+			vms: []recommender.VirtualMachine{
+				{Type: "t100", AvgPrice: 1, InterruptionRisk: &low},
+				{Type: "t200", AvgPrice: 1, InterruptionRisk: &high},
+				{Type: "t300", AvgPrice: 1},
+			},
+			maxRisk: 11,
+			check: func(filtered []recommender.VirtualMachine) {
+				assert.Equal(t, 2, len(filtered), "vm is not filtered out")
+				assert.Equal(t, "t100", filtered[0].Type)
+				assert.Equal(t, "t300", filtered[1].Type)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test // scopelint
+		t.Run(test.name, func(t *testing.T) {
+			selector := NewVmSelector(logur.NewTestLogger())
+			test.check(selector.filterInterruptionRisk(test.vms, test.maxRisk))
+		})
+	}
+}
+
 func TestVmSelector_ntwPerformanceFilter(t *testing.T) {
 	var (
 		ntwLow  = "low"
@@ -466,3 +498,114 @@ func TestVmSelector_currentGenFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestVmSelector_ntwBandwidthFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		vm    recommender.VirtualMachine
+		check func(passed bool)
+	}{
+		{
+			name: "vm passes the network bandwidth filter",
+			vm:   recommender.VirtualMachine{NetworkGbps: 25},
+			check: func(passed bool) {
+				assert.True(t, passed, "vm should pass the check")
+			},
+		},
+		{
+			name: "vm with unknown bandwidth doesn't pass the network bandwidth filter",
+			vm:   recommender.VirtualMachine{NetworkPerf: "Moderate"},
+			check: func(passed bool) {
+				assert.False(t, passed, "vm should not pass the check")
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test // scopelint
+		t.Run(test.name, func(t *testing.T) {
+			selector := NewVmSelector(logur.NewTestLogger())
+			req := recommender.SingleClusterRecommendationReq{
+				ClusterRecommendationReq: recommender.ClusterRecommendationReq{MinNetworkGbps: 10},
+			}
+			test.check(selector.ntwBandwidthFilter(test.vm, req))
+		})
+	}
+}
+
+func TestVmSelector_localStorageFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		req   recommender.ClusterRecommendationReq
+		vm    recommender.VirtualMachine
+		check func(passed bool)
+	}{
+		{
+			name: "nvme disk passes the ssd filter",
+			req:  recommender.ClusterRecommendationReq{MinLocalStorage: 100, LocalStorageType: recommender.StorageSsd},
+			vm:   recommender.VirtualMachine{LocalStorage: 150, LocalStorageType: recommender.StorageNvme},
+			check: func(passed bool) {
+				assert.True(t, passed, "vm should pass the check")
+			},
+		},
+		{
+			name: "ssd disk doesn't pass the nvme filter",
+			req:  recommender.ClusterRecommendationReq{LocalStorageType: recommender.StorageNvme},
+			vm:   recommender.VirtualMachine{LocalStorage: 150, LocalStorageType: recommender.StorageSsd},
+			check: func(passed bool) {
+				assert.False(t, passed, "vm should not pass the check")
+			},
+		},
+		{
+			name: "small disk doesn't pass the capacity filter",
+			req:  recommender.ClusterRecommendationReq{MinLocalStorage: 200},
+			vm:   recommender.VirtualMachine{LocalStorage: 150, LocalStorageType: recommender.StorageHdd},
+			check: func(passed bool) {
+				assert.False(t, passed, "vm should not pass the check")
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test // scopelint
+		t.Run(test.name, func(t *testing.T) {
+			selector := NewVmSelector(logur.NewTestLogger())
+			test.check(selector.localStorageFilter(test.vm, recommender.SingleClusterRecommendationReq{ClusterRecommendationReq: test.req}))
+		})
+	}
+}
+
+func TestVmSelector_windowsFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		vm    recommender.VirtualMachine
+		check func(passed bool)
+	}{
+		{
+			name: "x86 instance type passes the filter",
+			vm:   recommender.VirtualMachine{Type: "m5.large"},
+			check: func(passed bool) {
+				assert.True(t, passed, "vm should pass the check")
+			},
+		},
+		{
+			name: "graviton instance type doesn't pass the filter",
+			vm:   recommender.VirtualMachine{Type: "c6gd.xlarge"},
+			check: func(passed bool) {
+				assert.False(t, passed, "vm should not pass the check")
+			},
+		},
+		{
+			name: "first generation arm instance type doesn't pass the filter",
+			vm:   recommender.VirtualMachine{Type: "a1.large"},
+			check: func(passed bool) {
+				assert.False(t, passed, "vm should not pass the check")
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test // scopelint
+		t.Run(test.name, func(t *testing.T) {
+			selector := NewVmSelector(logur.NewTestLogger())
+			test.check(selector.windowsFilter(test.vm, recommender.SingleClusterRecommendationReq{}))
+		})
+	}
+}
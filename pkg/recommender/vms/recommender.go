@@ -15,6 +15,7 @@
 package vms
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/banzaicloud/telescopes/pkg/recommender"
@@ -34,12 +35,16 @@ func NewVmSelector(log logur.Logger) *vmSelector {
 }
 
 // RecommendVms selects a slice of VirtualMachines for the given attribute and requirements in the request
-func (s *vmSelector) RecommendVms(provider string,
+func (s *vmSelector) RecommendVms(ctx context.Context,
+	provider string,
 	vms []recommender.VirtualMachine,
 	attr string,
 	req recommender.SingleClusterRecommendationReq,
 	layout []recommender.NodePool,
 ) ([]recommender.VirtualMachine, []recommender.VirtualMachine, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
 	s.log.Info("recommending virtual machines", map[string]interface{}{"attribute": attr})
 
 	vmFilters, err := s.filtersForAttr(attr, provider, req)
@@ -80,6 +85,9 @@ func (s *vmSelector) RecommendVms(provider string,
 	if req.OnDemandPct < 100 {
 		// retain only the nodes that are available as spot instances
 		spotVms = s.filterSpots(spotVms)
+		if req.MaxInterruptionRisk != nil {
+			spotVms = s.filterInterruptionRisk(spotVms, *req.MaxInterruptionRisk)
+		}
 		if len(spotVms) == 0 {
 			s.log.Debug("no vms suitable for spot pools", map[string]interface{}{"attribute": attr})
 			return []recommender.VirtualMachine{}, []recommender.VirtualMachine{}, nil
@@ -89,7 +97,8 @@ func (s *vmSelector) RecommendVms(provider string,
 	return odVms, spotVms, nil
 }
 
-func (s *vmSelector) FindVmsWithAttrValues(attr string,
+func (s *vmSelector) FindVmsWithAttrValues(ctx context.Context,
+	attr string,
 	req recommender.SingleClusterRecommendationReq,
 	layoutDesc []recommender.NodePoolDesc,
 	allProducts []recommender.VirtualMachine,
@@ -99,6 +108,9 @@ func (s *vmSelector) FindVmsWithAttrValues(attr string,
 		values []float64
 		err    error
 	)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	if layoutDesc == nil {
 		values, err = s.recommendAttrValues(allProducts, attr, req)
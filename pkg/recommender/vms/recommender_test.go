@@ -15,6 +15,7 @@
 package vms
 
 import (
+	"context"
 	"testing"
 
 	"github.com/banzaicloud/telescopes/pkg/recommender"
@@ -128,8 +129,11 @@ func TestVmSelector_RecommendVms(t *testing.T) {
 			OnDemandPrice: 0.0949995,
 		},
 	}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
 	tests := []struct {
 		name      string
+		ctx       context.Context
 		values    []float64
 		request   recommender.SingleClusterRecommendationReq
 		attribute string
@@ -137,6 +141,7 @@ func TestVmSelector_RecommendVms(t *testing.T) {
 	}{
 		{
 			name:   "recommend three vm-s",
+			ctx:    context.Background(),
 			values: []float64{2},
 			request: recommender.SingleClusterRecommendationReq{
 				ClusterRecommendationReq: recommender.ClusterRecommendationReq{
@@ -154,12 +159,32 @@ func TestVmSelector_RecommendVms(t *testing.T) {
 				assert.Equal(t, 3, len(spotVms))
 			},
 		},
+		{
+			name:   "cancelled request",
+			ctx:    cancelled,
+			values: []float64{2},
+			request: recommender.SingleClusterRecommendationReq{
+				ClusterRecommendationReq: recommender.ClusterRecommendationReq{
+					MinNodes:    3,
+					MaxNodes:    3,
+					OnDemandPct: 100,
+					SumCpu:      6,
+					SumMem:      13,
+				},
+			},
+			attribute: recommender.Cpu,
+			check: func(odVms []recommender.VirtualMachine, spotVms []recommender.VirtualMachine, err error) {
+				assert.Equal(t, context.Canceled, err)
+				assert.Nil(t, odVms)
+				assert.Nil(t, spotVms)
+			},
+		},
 	}
 	for _, test := range tests {
 		test := test // scopelint
 		t.Run(test.name, func(t *testing.T) {
 			selector := NewVmSelector(logur.NewTestLogger())
-			test.check(selector.RecommendVms("google", vms, test.attribute, test.request, nil))
+			test.check(selector.RecommendVms(test.ctx, "google", vms, test.attribute, test.request, nil))
 		})
 	}
 }
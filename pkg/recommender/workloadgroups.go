@@ -0,0 +1,121 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goph/emperror"
+	"github.com/pkg/errors"
+)
+
+// RecommendClusterWorkloadGroups recommends dedicated node pools for every workload group of the request
+func (e *Engine) RecommendClusterWorkloadGroups(ctx context.Context, provider string, service string, region string, req ClusterWorkloadGroupsReq) (*ClusterWorkloadGroupsResp, error) {
+	e.log.Info(fmt.Sprintf("recommending node pools for workload groups. request: [%#v]", req))
+
+	names := make(map[string]bool, len(req.Groups))
+	for _, group := range req.Groups {
+		if names[group.Name] {
+			return nil, emperror.With(errors.Errorf("duplicate workload group: %s", group.Name), RecommenderErrorTag)
+		}
+		names[group.Name] = true
+	}
+
+	allProducts, err := e.getProductDetails(ctx, provider, service, region)
+	if err != nil {
+		return nil, err
+	}
+
+	master, err := e.recommendMaster(ctx, provider, service, SingleClusterRecommendationReq{Zone: req.Zone}, allProducts, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var clusterNodePools []NodePool
+	licensed := make(map[string][]VirtualMachine)
+	groups := make([]WorkloadGroupNodePools, 0, len(req.Groups))
+	for _, group := range req.Groups {
+		groupReq := SingleClusterRecommendationReq{
+			ClusterRecommendationReq: group.ClusterRecommendationReq,
+			Excludes:                 group.Excludes,
+			Includes:                 group.Includes,
+			Zone:                     req.Zone,
+		}
+		if groupReq.OnDemandPct != 100 && !spotPriceAvailable(allProducts) {
+			e.log.Warn("onDemand percentage in the request ignored", map[string]interface{}{"group": group.Name})
+			groupReq.OnDemandPct = 100
+		}
+
+		if _, ok := licensed[groupReq.Os]; !ok {
+			licensed[groupReq.Os] = licensedProducts(allProducts, groupReq.Os)
+		}
+
+		nodePools, err := e.getCheapestNodePoolSet(ctx, provider, withHeadroom(groupReq), nil, licensed[groupReq.Os])
+		if err != nil {
+			return nil, emperror.WrapWith(err, "failed to recommend node pools for workload group", RecommenderErrorTag, "group", group.Name)
+		}
+		if groupReq.SumPods > 0 {
+			nodePools, err = e.ensurePodCapacity(nodePools, groupReq.SumPods, groupReq.MaxPodsPerNode, groupReq.MaxNodes)
+			if err != nil {
+				return nil, emperror.WrapWith(err, "failed to recommend node pools for workload group", "group", group.Name)
+			}
+		}
+		nodePools = withOs(withRawVmTypes(nodePools, allProducts), groupReq.Os)
+
+		accuracy := findResponseSum(req.Zone, nodePools)
+		accuracy.RecPods = podCapacity(nodePools, groupReq.MaxPodsPerNode)
+		accuracy.RecLicensePrice = licensePrice(nodePools)
+
+		groups = append(groups, WorkloadGroupNodePools{
+			Name:      group.Name,
+			NodePools: nodePools,
+			Labels:    map[string]string{WorkloadGroupKey: group.Name},
+			Taints:    workloadGroupTaints(group),
+			Accuracy:  accuracy,
+		})
+		clusterNodePools = append(clusterNodePools, nodePools...)
+	}
+
+	if master != nil {
+		clusterNodePools = append(clusterNodePools, *master)
+	}
+
+	accuracy := findResponseSum(req.Zone, clusterNodePools)
+	accuracy.RecLicensePrice = licensePrice(clusterNodePools)
+	accuracy, err = e.withCostComponents(ctx, provider, service, region, req.Zone, clusterNodePools, accuracy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClusterWorkloadGroupsResp{
+		Provider: provider,
+		Service:  service,
+		Region:   region,
+		Zone:     req.Zone,
+		Groups:   groups,
+		Master:   master,
+		Accuracy: accuracy,
+		Stale:    e.staleProducts(provider, service, region),
+	}, nil
+}
+
+// workloadGroupTaints returns the taints keeping other workloads off the node pools of a dedicated group
+func workloadGroupTaints(group WorkloadGroupReq) []Taint {
+	if !group.Dedicated {
+		return nil
+	}
+	return []Taint{{Key: WorkloadGroupKey, Value: group.Name, Effect: "NoSchedule"}}
+}
@@ -0,0 +1,77 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goph/logur"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEngine_RecommendClusterWorkloadGroups(t *testing.T) {
+	groupReq := ClusterRecommendationReq{
+		MinNodes: 1,
+		MaxNodes: 1,
+		SumMem:   32,
+		SumCpu:   16,
+	}
+	tests := []struct {
+		name    string
+		request ClusterWorkloadGroupsReq
+		check   func(resp *ClusterWorkloadGroupsResp, err error)
+	}{
+		{
+			name: "workload groups recommendation success",
+			request: ClusterWorkloadGroupsReq{
+				Groups: []WorkloadGroupReq{
+					{Name: "system", Dedicated: true, ClusterRecommendationReq: groupReq},
+					{Name: "general", ClusterRecommendationReq: groupReq},
+				},
+			},
+			check: func(resp *ClusterWorkloadGroupsResp, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 2, len(resp.Groups))
+				assert.Equal(t, "system", resp.Groups[0].Labels[WorkloadGroupKey])
+				assert.Equal(t, []Taint{{Key: WorkloadGroupKey, Value: "system", Effect: "NoSchedule"}}, resp.Groups[0].Taints)
+				assert.Nil(t, resp.Groups[1].Taints)
+				assert.Equal(t, float64(2), resp.Groups[0].Accuracy.RecTotalPrice)
+				assert.Equal(t, float64(4), resp.Accuracy.RecTotalPrice)
+			},
+		},
+		{
+			name: "workload group names must be unique",
+			request: ClusterWorkloadGroupsReq{
+				Groups: []WorkloadGroupReq{
+					{Name: "general", ClusterRecommendationReq: groupReq},
+					{Name: "general", ClusterRecommendationReq: groupReq},
+				},
+			},
+			check: func(resp *ClusterWorkloadGroupsResp, err error) {
+				assert.Nil(t, resp, "the response should be nil")
+				assert.EqualError(t, err, "duplicate workload group: general")
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			engine := NewEngine(logur.NewTestLogger(), &dummyProducts{}, &dummyVms{}, &dummyNodePools{})
+
+			test.check(engine.RecommendClusterWorkloadGroups(context.Background(), "dummyProvider", "dummyService", "dummyRegion", test.request))
+		})
+	}
+}